@@ -0,0 +1,144 @@
+package zn
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/reg0007/Zn/exec"
+)
+
+// replConfig - REPL config, which could be customized from `~/.znrc`
+//
+// The config file consists of `key = value` lines, and lines start with `#` are comments:
+//
+// # prompt text
+// prompt = Zn>
+// prompt.continue = ..>
+// # history file (leave it empty to disable persistent history)
+// history = ~/.zn_history
+// # default precision (number of significant digits) of division results
+// precision = 8
+// # default rounding mode of division results (四舍五入, 四舍六入五成双, 向下, 向上 or 截断)
+// rounding = 四舍五入
+// # colors (xterm 256 colors, `none` for no color) of result values
+// color.decimal = 147
+// color.string = 184
+// color.bool = 231
+// color.null = 80
+type replConfig struct {
+	prompt         string
+	continuePrompt string
+	historyFile    string
+	precision      int
+	rounding       exec.RoundingMode
+	colors         map[string]string
+}
+
+const configFileName = ".znrc"
+
+func defaultReplConfig() *replConfig {
+	historyFile := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		historyFile = filepath.Join(home, ".zn_history")
+	}
+	return &replConfig{
+		prompt:         "Zn>",
+		continuePrompt: "..>",
+		historyFile:    historyFile,
+		precision:      8,
+		rounding:       exec.RoundHalfUp,
+		colors: map[string]string{
+			"decimal": "147", // Cyan (lightblue)
+			"string":  "184", // Green
+			"bool":    "231", // White
+			"null":    "80",
+		},
+	}
+}
+
+// loadReplConfig - load config from `~/.znrc`. If the file doesn't exist, the default config is used.
+// Invalid lines are ignored with warnings.
+func loadReplConfig() *replConfig {
+	cfg := defaultReplConfig()
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return cfg
+	}
+	path := filepath.Join(home, configFileName)
+	file, err := os.Open(path)
+	if err != nil {
+		return cfg
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := cfg.set(line, home); err != nil {
+			fmt.Printf("配置文件「%s」第 %d 行无效：%s\n", path, lineNum, err.Error())
+		}
+	}
+	return cfg
+}
+
+// set - set config item from a `key = value` line
+func (cfg *replConfig) set(line string, home string) error {
+	idx := strings.Index(line, "=")
+	if idx < 0 {
+		return fmt.Errorf("须为「键 = 值」的格式")
+	}
+	key := strings.TrimSpace(line[:idx])
+	value := strings.TrimSpace(line[idx+1:])
+
+	switch key {
+	case "prompt":
+		cfg.prompt = value
+	case "prompt.continue":
+		cfg.continuePrompt = value
+	case "history":
+		if strings.HasPrefix(value, "~/") {
+			value = filepath.Join(home, value[2:])
+		}
+		cfg.historyFile = value
+	case "precision":
+		precision, err := strconv.Atoi(value)
+		if err != nil || precision <= 0 {
+			return fmt.Errorf("精度须为正整数")
+		}
+		cfg.precision = precision
+	case "rounding":
+		mode, ok := exec.RoundingModeNames[value]
+		if !ok {
+			return fmt.Errorf("未知的舍入模式「%s」", value)
+		}
+		cfg.rounding = mode
+	case "color.decimal", "color.string", "color.bool", "color.null":
+		if value != "none" {
+			if code, err := strconv.Atoi(value); err != nil || code < 0 || code > 255 {
+				return fmt.Errorf("颜色须为 0 至 255 之间的整数，或为 none")
+			}
+		}
+		cfg.colors[strings.TrimPrefix(key, "color.")] = value
+	default:
+		return fmt.Errorf("未知的配置项「%s」", key)
+	}
+	return nil
+}
+
+// colorize - wrap text with the color of given value type
+func (cfg *replConfig) colorize(valueType string, text string) string {
+	color, ok := cfg.colors[valueType]
+	if !ok || color == "none" {
+		return text
+	}
+	return fmt.Sprintf("\x1b[38;5;%sm%s\x1b[0m", color, text)
+}
@@ -0,0 +1,405 @@
+package zn
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/peterh/liner"
+	"github.com/reg0007/Zn/exec"
+	"github.com/reg0007/Zn/lex"
+	"github.com/reg0007/Zn/syntax"
+	"github.com/reg0007/Zn/util"
+)
+
+// replSession - the state of a REPL session
+type replSession struct {
+	ctx    *exec.Context
+	scope  *exec.RootScope
+	config *replConfig
+}
+
+// replCommand - REPL dot-command (e.g. `.print`)
+type replCommand struct {
+	name    string
+	usage   string
+	desc    string
+	handler func(s *replSession, arg string)
+}
+
+var replCommands []replCommand
+
+func newReplSession(config *replConfig) *replSession {
+	ctx := exec.NewContext()
+	ctx.SetPrecision(config.precision)
+	ctx.SetRoundingMode(config.rounding)
+	return &replSession{
+		ctx:    ctx,
+		scope:  exec.NewRootScope(),
+		config: config,
+	}
+}
+
+// EnterREPL - enter REPL to handle data
+func EnterREPL() {
+	linerR := liner.NewLiner()
+	linerR.SetCtrlCAborts(true)
+	config := loadReplConfig()
+	session := newReplSession(config)
+	linerR.SetWordCompleter(session.completeWord)
+	linerR.SetTabCompletionStyle(liner.TabPrints)
+
+	// load history, and save it on exit
+	loadHistory(linerR, config.historyFile)
+	defer func() {
+		saveHistory(linerR, config.historyFile)
+		linerR.Close()
+	}()
+
+	// lines of current (incomplete) input
+	lines := []string{}
+	// inBlock - if a block (e.g. 如果...：) is being input, which should be terminated by an empty line
+	inBlock := false
+	// REPL loop
+	for {
+		prompt := config.prompt + " "
+		if len(lines) > 0 {
+			prompt = config.continuePrompt + " "
+		}
+		text, err := linerR.Prompt(prompt)
+		if err != nil {
+			if err == liner.ErrPromptAborted {
+				// abort current multi-line input only
+				if len(lines) > 0 {
+					lines = []string{}
+					inBlock = false
+					continue
+				}
+				return
+			} else if err.Error() == "EOF" {
+				return
+			} else {
+				fmt.Printf("未知错误：%s\n", err.Error())
+				return
+			}
+		}
+		// append history
+		if strings.TrimSpace(text) != "" {
+			linerR.AppendHistory(text)
+		}
+		// add special command
+		if len(lines) == 0 && strings.HasPrefix(text, ".") {
+			if strings.TrimSpace(text) == ".exit" {
+				return
+			}
+			session.execCommand(text)
+			continue
+		}
+
+		// wait for more lines if the input is incomplete
+		if inBlock {
+			if strings.TrimSpace(text) != "" {
+				lines = append(lines, text)
+				continue
+			}
+		} else {
+			lines = append(lines, text)
+			if incomplete, isBlock := checkIncomplete(strings.Join(lines, "\n")); incomplete {
+				inBlock = isBlock
+				continue
+			}
+		}
+		code := strings.Join(lines, "\n")
+		lines = []string{}
+		inBlock = false
+
+		// execute program
+		session.execCode(lex.NewTextStream(code), true)
+	}
+}
+
+// loadHistory - load REPL history from file (if exists)
+func loadHistory(linerR *liner.State, path string) {
+	if path == "" {
+		return
+	}
+	if f, err := os.Open(path); err == nil {
+		linerR.ReadHistory(f)
+		f.Close()
+	}
+}
+
+// saveHistory - save REPL history to file
+func saveHistory(linerR *liner.State, path string) {
+	if path == "" {
+		return
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Printf("无法保存历史记录：%s\n", err.Error())
+		return
+	}
+	linerR.WriteHistory(f)
+	f.Close()
+}
+
+// execCode - execute code under current session, and display the result (if showValue = true).
+// During execution, press Ctrl+C to cancel it.
+func (s *replSession) execCode(in *lex.InputStream, showValue bool) {
+	goCtx, cancel := context.WithCancel(context.Background())
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		if _, ok := <-sigCh; ok {
+			cancel()
+		}
+	}()
+	defer func() {
+		signal.Stop(sigCh)
+		close(sigCh)
+		cancel()
+	}()
+
+	result := s.ctx.ExecuteCodeWithContext(goCtx, in, s.scope)
+	if !result.HasError {
+		if showValue && result.Value != nil {
+			prettyDisplayValue(result.Value, s.config, os.Stdout)
+		}
+	} else {
+		fmt.Println(result.Error.Display())
+	}
+}
+
+// execCommand - execute dot-command, e.g. `.load 快速排序.zn`
+func (s *replSession) execCommand(text string) {
+	text = strings.TrimSpace(text)
+	name, arg := text, ""
+	if idx := strings.IndexAny(text, " \t"); idx >= 0 {
+		name, arg = text[:idx], strings.TrimSpace(text[idx+1:])
+	}
+	for _, cmd := range replCommands {
+		if cmd.name == name && cmd.handler != nil {
+			cmd.handler(s, arg)
+			return
+		}
+	}
+	fmt.Printf("未知命令「%s」，输入 .help 查看所有命令\n", name)
+}
+
+// .print - list all symbols & classes of current session
+func printSymbols(s *replSession, arg string) {
+	symbols := s.scope.GetSymbols()
+	names := []string{}
+	for name := range symbols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sym := symbols[name]
+		flag := ""
+		if sym.IsConstant {
+			flag = "，常量"
+		}
+		fmt.Printf("%s（%s%s）= %s\n", name, exec.GetTypeName(sym.Value), flag, sym.Value.String())
+	}
+
+	classNames := []string{}
+	for name := range s.scope.GetClassRefs() {
+		classNames = append(classNames, name)
+	}
+	sort.Strings(classNames)
+	for _, name := range classNames {
+		fmt.Printf("%s（类）\n", name)
+	}
+}
+
+// .type <expr> - display the type of an expression
+func printType(s *replSession, arg string) {
+	if arg == "" {
+		fmt.Println("用法：.type 〔表达式〕")
+		return
+	}
+	// only a single expression is accepted, thus .type never declares or assigns
+	// variables of current session (e.g. .type 令A为1)
+	block, err := syntax.NewParser(lex.NewLexer(lex.NewTextStream(arg))).Parse()
+	if err != nil {
+		fmt.Println(err.Display())
+		return
+	}
+	if len(block.Children) != 1 || !isPureExpression(block.Children[0]) {
+		fmt.Println("只能显示单个表达式的类型，不能声明或赋值变量")
+		return
+	}
+	result := s.ctx.ExecuteCode(lex.NewTextStream(arg), s.scope)
+	if result.HasError {
+		fmt.Println(result.Error.Display())
+		return
+	}
+	fmt.Println(exec.GetTypeName(result.Value))
+}
+
+// isPureExpression - if the statement is an expression other than assignments (e.g. A为1)
+func isPureExpression(stmt syntax.Statement) bool {
+	if _, ok := stmt.(syntax.Expression); !ok {
+		return false
+	}
+	_, isAssign := stmt.(*syntax.VarAssignExpr)
+	return !isAssign
+}
+
+// .load <file> - execute a file under current session, thus all symbols of
+// the file could be used afterwards
+func loadFile(s *replSession, arg string) {
+	if arg == "" {
+		fmt.Println("用法：.load 〔文件路径〕")
+		return
+	}
+	in, err := lex.NewFileStream(arg)
+	if err != nil {
+		fmt.Println(err.Display())
+		return
+	}
+	s.execCode(in, false)
+}
+
+// .reset - clear all symbols & classes of current session
+func resetSession(s *replSession, arg string) {
+	*s = *newReplSession(s.config)
+	fmt.Println("已清空当前会话")
+}
+
+// .help - show all commands
+func printHelp(s *replSession, arg string) {
+	for _, cmd := range replCommands {
+		fmt.Printf("%s\n    %s\n", strings.TrimSpace(cmd.name+" "+cmd.usage), cmd.desc)
+	}
+}
+
+// completeWord - complete the word (before cursor) with keywords, commands and
+// symbols defined in current session.
+func (s *replSession) completeWord(line string, pos int) (head string, completions []string, tail string) {
+	lineR := []rune(line)
+	if pos > len(lineR) {
+		pos = len(lineR)
+	}
+	head, tail = string(lineR[:pos]), string(lineR[pos:])
+
+	// for dot-commands
+	if strings.HasPrefix(head, ".") && !strings.ContainsAny(head, " \t") {
+		for _, cmd := range replCommands {
+			if strings.HasPrefix(cmd.name, head) {
+				completions = append(completions, cmd.name)
+			}
+		}
+		return "", completions, tail
+	}
+
+	// insert indents (when there's only whitespaces before cursor)
+	if strings.TrimSpace(head) == "" {
+		return head, []string{"    "}, tail
+	}
+
+	candidates := map[string]bool{}
+	for _, kw := range lex.KeywordTypeMap {
+		candidates[string(kw)] = true
+	}
+	for name := range s.ctx.GetGlobals() {
+		candidates[name] = true
+	}
+	for name := range s.scope.GetSymbols() {
+		candidates[name] = true
+	}
+	for name := range s.scope.GetClassRefs() {
+		candidates[name] = true
+	}
+
+	// find the word to complete. Since keywords & identifiers are usually written
+	// without spaces (e.g. 令甲为...), the longest suffix that matches any candidates is used.
+	start := pos
+	for start > 0 && isWordChar(lineR[start-1]) {
+		start--
+	}
+	for ; start < pos; start++ {
+		word := string(lineR[start:pos])
+		for name := range candidates {
+			if strings.HasPrefix(name, word) && name != word {
+				completions = append(completions, name)
+			}
+		}
+		if len(completions) > 0 {
+			sort.Strings(completions)
+			return string(lineR[:start]), completions, tail
+		}
+	}
+	return head, nil, tail
+}
+
+// isWordChar - if the char could be part of a keyword or an identifier
+func isWordChar(ch rune) bool {
+	if util.Contains(ch, lex.WhiteSpaces) || util.Contains(ch, lex.MarkLeads) {
+		return false
+	}
+	if util.Contains(ch, lex.LeftQuotes) || util.Contains(ch, lex.RightQuotes) {
+		return false
+	}
+	return true
+}
+
+// checkIncomplete - check if the input code is an incomplete statement, thus more lines
+// are expected. An input is incomplete when:
+//
+// 1. there're quotes or brackets not closed yet, e.g. `【1，2`, `「你好`
+// 2. the last token is `，`, `：` or `？`
+//
+// If the last token is `：` or `？`, a block (whose indents are expected) is opened
+// and isBlock = true. A block could only be terminated by an empty line.
+func checkIncomplete(code string) (incomplete bool, isBlock bool) {
+	l := lex.NewLexer(lex.NewTextStream(code))
+	brackets := 0
+	var last *lex.Token
+	for {
+		tk, err := l.NextToken()
+		// leave lex errors to the executor
+		if err != nil {
+			return false, false
+		}
+		switch tk.Type {
+		case lex.TypeEOF:
+			if l.HasOpenQuotes() || brackets > 0 {
+				return true, false
+			}
+			if last == nil {
+				return false, false
+			}
+			switch last.Type {
+			case lex.TypeFuncCall, lex.TypeFuncDeclare:
+				return true, true
+			case lex.TypeCommaSep:
+				return true, false
+			}
+			return false, false
+		case lex.TypeComment:
+			continue
+		case lex.TypeArrayQuoteL, lex.TypeFuncQuoteL, lex.TypeStmtQuoteL, lex.TypeMapQHash:
+			brackets++
+		case lex.TypeArrayQuoteR, lex.TypeFuncQuoteR, lex.TypeStmtQuoteR:
+			brackets--
+		}
+		last = tk
+	}
+}
+
+func init() {
+	replCommands = []replCommand{
+		{".print", "", "显示当前会话中定义的所有变量及类", printSymbols},
+		{".type", "〔表达式〕", "显示表达式之值的类型", printType},
+		{".load", "〔文件路径〕", "于当前会话中执行文件，其定义的变量及类可于之后使用", loadFile},
+		{".reset", "", "清空当前会话中定义的所有变量及类", resetSession},
+		{".help", "", "显示所有命令", printHelp},
+		{".exit", "", "退出交互模式", nil},
+	}
+}
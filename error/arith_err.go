@@ -23,6 +23,61 @@ func ToIntegerError(raw string) *Error {
 	})
 }
 
+// ArithSqrtNegativeError - for √A, when A < 0
+func ArithSqrtNegativeError() *Error {
+	return arithError.NewError(0x04, Error{
+		text: "不得对负数开方",
+	})
+}
+
+// InvalidRoundingMode -
+func InvalidRoundingMode(name string) *Error {
+	return arithError.NewError(0x05, Error{
+		text: fmt.Sprintf("舍入模式「%s」无效", name),
+		info: fmt.Sprintf("mode=(%s)", name),
+	})
+}
+
+// InvalidPrecision -
+func InvalidPrecision(precision int) *Error {
+	return arithError.NewError(0x06, Error{
+		text: fmt.Sprintf("精度须为正整数，而非 %d", precision),
+		info: fmt.Sprintf("precision=(%d)", precision),
+	})
+}
+
+// CurrencyMismatch - for money A & B of different currencies, e.g. ¥10 ＋ 10美元
+func CurrencyMismatch(currency1 string, currency2 string) *Error {
+	return arithError.NewError(0x07, Error{
+		text: fmt.Sprintf("不同币种（%s、%s）的金额不能直接运算", currency1, currency2),
+		info: fmt.Sprintf("currency1=(%s) currency2=(%s)", currency1, currency2),
+	})
+}
+
+// DurationOutOfRange - a duration should be within ±2562047小时 (about 292 years)
+func DurationOutOfRange(value string) *Error {
+	return arithError.NewError(0x08, Error{
+		text: fmt.Sprintf("时长「%s」超出范围：时长须在约±292年之内", value),
+		info: fmt.Sprintf("value=(%s)", value),
+	})
+}
+
+// DigitsOutOfRange - the result has too many digits to calculate, e.g. 2 ^ 100000000
+func DigitsOutOfRange(max int) *Error {
+	return arithError.NewError(0x09, Error{
+		text: fmt.Sprintf("计算结果超出范围：数字的位数须在%d位之内", max),
+		info: fmt.Sprintf("max=(%d)", max),
+	})
+}
+
+// PlacesOutOfRange - the number of decimal places (e.g. of 四舍五入) should be within ±max
+func PlacesOutOfRange(places int, max int) *Error {
+	return arithError.NewError(0x0A, Error{
+		text: fmt.Sprintf("小数位数「%d」超出范围：位数须在±%d之内", places, max),
+		info: fmt.Sprintf("places=(%d) max=(%d)", places, max),
+	})
+}
+
 const (
 	// ErrCodeArithDivZero -
 	ErrCodeArithDivZero = (ArithErrorClass << 16) & 0x01
@@ -17,6 +17,8 @@ type Error struct {
 	info string
 	// extra data (any type)
 	extra interface{}
+	// stack - call stack when the error occurs (innermost frame first)
+	stack []StackFrame
 
 	displayMask uint16
 }
@@ -57,11 +59,27 @@ func (e *Error) GetExtra() interface{} {
 	return e.extra
 }
 
+// SetStack - set call stack when the error occurs
+func (e *Error) SetStack(stack []StackFrame) {
+	e.stack = stack
+}
+
+// GetStack - get call stack when the error occurs (innermost frame first).
+// return nil if the error doesn't occur inside any function.
+func (e *Error) GetStack() []StackFrame {
+	return e.stack
+}
+
 // GetErrorClass - get error class
 func (e *Error) GetErrorClass() int {
 	return int(e.code >> 8)
 }
 
+// GetErrorClassName - get the display name of error class (e.g. 类型错误)
+func (e *Error) GetErrorClassName() string {
+	return errClassMap[e.code>>8]
+}
+
 // GetInfo - get (parsed) info
 func (e *Error) GetInfo() map[string]string {
 	var infoMap = map[string]string{}
@@ -92,6 +110,12 @@ func (e *Error) GetInfo() map[string]string {
 //     如果代码不为空：
 //    ^
 // ‹2021› 语法错误：此行现行缩进类型为「TAB」，与前设缩进类型「空格」不符！
+//
+// If the error occurs inside functions, the call stack will be displayed as well:
+//
+// 调用栈：
+//     在「draft/example.zn」第 12 行，方法「求和」之中
+//     在「draft/example.zn」第 20 行
 func (e *Error) Display() string {
 	var line1, line2, line3, line4 string
 	// line1
@@ -136,6 +160,13 @@ func (e *Error) Display() string {
 			texts = append(texts, line)
 		}
 	}
+	// call stack
+	if len(e.stack) > 0 {
+		texts = append(texts, "调用栈：")
+		for _, frame := range e.stack {
+			texts = append(texts, "    "+frame.String())
+		}
+	}
 	return strings.Join(texts, "\n")
 }
 
@@ -200,6 +231,23 @@ type Cursor struct {
 	Text    string
 }
 
+// StackFrame denotes one frame of the call stack - the function (or method, getter)
+// and the position being executed inside it.
+// For the outermost frame (i.e. the program itself), Name is empty.
+type StackFrame struct {
+	Name    string
+	File    string
+	LineNum int
+}
+
+// String - display the frame, e.g. 在「draft/example.zn」第 12 行，方法「求和」之中
+func (f StackFrame) String() string {
+	if f.Name == "" {
+		return fmt.Sprintf("在「%s」第 %d 行", f.File, f.LineNum)
+	}
+	return fmt.Sprintf("在「%s」第 %d 行，方法「%s」之中", f.File, f.LineNum, f.Name)
+}
+
 // ErrorClass defines the prefix of error code
 type errorClass struct {
 	prefix   uint16
@@ -220,16 +268,18 @@ func (ec *errorClass) NewError(subcode uint16, model Error) *Error {
 
 // define some error classes
 const (
-	LexErrorClass      = 0x20
-	IOErrorClass       = 0x21
-	SyntaxErrorClass   = 0x22
-	TypeErrorClass     = 0x23
-	IndexErrorClass    = 0x24
-	NameErrorClass     = 0x25
-	ArithErrorClass    = 0x26
-	ParamErrorClass    = 0x27
-	BreakErrorClass    = 0x50
-	InternalErrorClass = 0x60
+	LexErrorClass       = 0x20
+	IOErrorClass        = 0x21
+	SyntaxErrorClass    = 0x22
+	TypeErrorClass      = 0x23
+	IndexErrorClass     = 0x24
+	NameErrorClass      = 0x25
+	ArithErrorClass     = 0x26
+	ParamErrorClass     = 0x27
+	ExceptionErrorClass = 0x28
+	LimitErrorClass     = 0x29
+	BreakErrorClass     = 0x50
+	InternalErrorClass  = 0x60
 )
 
 // NewErrorSLOT - a tmp placeholder for adding errors quickly while the
@@ -271,6 +321,13 @@ var (
 	// 0x27 - paramError
 	// trigger error when input parameters doesn't satisfy the requirements
 	paramError = errorClass{ParamErrorClass, dpHideLineCursor}
+	// 0x28 - exceptionError
+	// errors raised from user code explicitly (i.e. 抛出 statement)
+	exceptionError = errorClass{ExceptionErrorClass, dpHideLineCursor}
+	// 0x29 - limitError
+	// execution is aborted since it exceeds the limits set by host program (or it's cancelled).
+	// NOTICE: limitError could NOT be caught by 捕获 block
+	limitError = errorClass{LimitErrorClass, dpHideLineCursor}
 	// 0x50 - breakError
 	// send a virtual BREAK interrupt to stop the process.
 	// NOTICE: breakError is NOT a true error!
@@ -280,16 +337,18 @@ var (
 	internalError = errorClass{InternalErrorClass, dpHideLineCursor}
 
 	errClassMap = map[uint16]string{
-		LexErrorClass:      "语法错误", // from lex
-		IOErrorClass:       "I/O错误",
-		SyntaxErrorClass:   "语法错误", // from parser
-		TypeErrorClass:     "类型错误",
-		IndexErrorClass:    "索引错误",
-		NameErrorClass:     "标识错误",
-		ArithErrorClass:    "算术错误",
-		ParamErrorClass:    "参数错误",
-		BreakErrorClass:    "中断信号",
-		InternalErrorClass: "内部错误",
+		LexErrorClass:       "语法错误", // from lex
+		IOErrorClass:        "I/O错误",
+		SyntaxErrorClass:    "语法错误", // from parser
+		TypeErrorClass:      "类型错误",
+		IndexErrorClass:     "索引错误",
+		NameErrorClass:      "标识错误",
+		ArithErrorClass:     "算术错误",
+		ParamErrorClass:     "参数错误",
+		ExceptionErrorClass: "异常",
+		LimitErrorClass:     "执行中止",
+		BreakErrorClass:     "中断信号",
+		InternalErrorClass:  "内部错误",
 	}
 )
 
@@ -123,6 +123,38 @@ func TestError_DisplayMasks(t *testing.T) {
 	}
 }
 
+func TestError_DisplayStack(t *testing.T) {
+	err := Error{
+		code: 0x2303,
+		text: "输入参数不符合期望之「数值」类型",
+		cursor: Cursor{
+			File:    "draft/example.zn",
+			LineNum: 3,
+			Text:    "返回（X+Y：甲，「a」）",
+		},
+		displayMask: dpHideLineCursor,
+	}
+	err.SetStack([]StackFrame{
+		{Name: "求和", File: "draft/example.zn", LineNum: 3},
+		{Name: "计算", File: "draft/lib.zn", LineNum: 6},
+		{File: "draft/example.zn", LineNum: 9},
+	})
+
+	expect := strings.Join([]string{
+		"在「draft/example.zn」中，位于第 3 行发现错误：",
+		"    返回（X+Y：甲，「a」）",
+		"    ",
+		"‹2303› 类型错误：输入参数不符合期望之「数值」类型",
+		"调用栈：",
+		"    在「draft/example.zn」第 3 行，方法「求和」之中",
+		"    在「draft/lib.zn」第 6 行，方法「计算」之中",
+		"    在「draft/example.zn」第 9 行",
+	}, "\n")
+	if got := err.Display(); got != expect {
+		t.Errorf("display result different:\n  expect ->\n%s\n  got->\n%s\n", expect, got)
+	}
+}
+
 func TestError_CalcCursorOffset(t *testing.T) {
 	text := "汉字TA汉字		245μg测试Ѣ2为什么"
 
@@ -0,0 +1,26 @@
+package error
+
+import "fmt"
+
+// ThrowException - raised by 抛出 statement with custom text
+func ThrowException(text string) *Error {
+	return exceptionError.NewError(0x01, Error{
+		text: text,
+	})
+}
+
+// ThrowInvalidValue - only string or exception value could be thrown
+func ThrowInvalidValue(value string) *Error {
+	return exceptionError.NewError(0x02, Error{
+		text: fmt.Sprintf("「%s」不能被抛出：只能抛出「文本」或「异常」类型的值", value),
+		info: fmt.Sprintf("value=(%s)", value),
+	})
+}
+
+// NoMatchedArm - no arm of 对于 statement matches the value, and there's no 否则 arm
+func NoMatchedArm(value string) *Error {
+	return exceptionError.NewError(0x03, Error{
+		text: fmt.Sprintf("「%s」未能匹配「对于」语句的任何分支，且未设置「否则」分支", value),
+		info: fmt.Sprintf("value=(%s)", value),
+	})
+}
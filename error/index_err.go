@@ -16,3 +16,10 @@ func IndexKeyNotFound(key string) *Error {
 		info: fmt.Sprintf("index=(%s)", key),
 	})
 }
+
+// IndexNotAssignable - e.g. the characters of a string could not be assigned
+func IndexNotAssignable() *Error {
+	return indexError.NewError(0x03, Error{
+		text: "此对象之索引不可被赋值",
+	})
+}
@@ -79,3 +79,20 @@ func InvalidChar(ch rune) *Error {
 		info: fmt.Sprintf("charcode=(%d)", ch),
 	})
 }
+
+// InvalidNumberLiteral - the number literal is ambiguous, e.g. 1万5 (use 1.5万 or 15000 instead)
+func InvalidNumberLiteral(literal string) *Error {
+	return lexError.NewError(0x27, Error{
+		text: fmt.Sprintf("数字「%s」不符合规范", literal),
+		info: fmt.Sprintf("literal=(%s)", literal),
+	})
+}
+
+// SignBeforeIdentifier - an ASCII sign right before an identifier is ambiguous, e.g. -A
+// (use - A or －A instead)
+func SignBeforeIdentifier(ch rune) *Error {
+	return lexError.NewError(0x28, Error{
+		text: fmt.Sprintf("「%c」不能紧接标识：作为运算符时，请在其后加空格或改用全角符号「%c」", ch, ch+0xFEE0),
+		info: fmt.Sprintf("charcode=(%d)", ch),
+	})
+}
@@ -0,0 +1,33 @@
+package error
+
+import "fmt"
+
+// ExecutionCancelled - the execution is cancelled by host program
+func ExecutionCancelled() *Error {
+	return limitError.NewError(0x01, Error{
+		text: "程序执行已被取消",
+	})
+}
+
+// ExecutionTimeout - the execution time exceeds the limit (or the deadline set by host program)
+func ExecutionTimeout() *Error {
+	return limitError.NewError(0x02, Error{
+		text: "程序执行时间超过时限",
+	})
+}
+
+// MaxStatementsExceeded - the number of executed statements exceeds the limit
+func MaxStatementsExceeded(limit int) *Error {
+	return limitError.NewError(0x03, Error{
+		text: fmt.Sprintf("执行语句数超过上限（%d）", limit),
+		info: fmt.Sprintf("limit=(%d)", limit),
+	})
+}
+
+// MaxCallDepthExceeded - the depth of function calls exceeds the limit
+func MaxCallDepthExceeded(limit int) *Error {
+	return limitError.NewError(0x04, Error{
+		text: fmt.Sprintf("方法调用层数超过上限（%d）", limit),
+		info: fmt.Sprintf("limit=(%d)", limit),
+	})
+}
@@ -40,3 +40,11 @@ func MethodNotFound(name string) *Error {
 		info: fmt.Sprintf("name=(%s)", name),
 	})
 }
+
+// ModuleCircularImport -
+func ModuleCircularImport(path string) *Error {
+	return nameError.NewError(0x06, Error{
+		text: fmt.Sprintf("模块「%s」被循环导入", path),
+		info: fmt.Sprintf("path=(%s)", path),
+	})
+}
@@ -33,3 +33,91 @@ func ExactParamsError(exactParams int) *Error {
 		info: fmt.Sprintf("maxParams=(%d)", exactParams),
 	})
 }
+
+// InvalidDateTime - the string could not be parsed as a date or time, e.g. 「2024年13月1日」
+func InvalidDateTime(raw string) *Error {
+	return paramError.NewError(0x04, Error{
+		text: fmt.Sprintf("「%s」不是有效的日期或时刻", raw),
+		info: fmt.Sprintf("raw=(%s)", raw),
+	})
+}
+
+// InvalidDuration - the string could not be parsed as a duration, e.g. 「3个月」
+func InvalidDuration(raw string) *Error {
+	return paramError.NewError(0x05, Error{
+		text: fmt.Sprintf("「%s」不是有效的时长", raw),
+		info: fmt.Sprintf("raw=(%s)", raw),
+	})
+}
+
+// InvalidTimeZone -
+func InvalidTimeZone(name string) *Error {
+	return paramError.NewError(0x06, Error{
+		text: fmt.Sprintf("时区「%s」无效", name),
+		info: fmt.Sprintf("name=(%s)", name),
+	})
+}
+
+// InvalidCurrency - e.g. 「XYZ」
+func InvalidCurrency(name string) *Error {
+	return paramError.NewError(0x07, Error{
+		text: fmt.Sprintf("币种「%s」无效", name),
+		info: fmt.Sprintf("name=(%s)", name),
+	})
+}
+
+// InvalidRatio - ratios of allocation should be non-negative and not all zeros
+func InvalidRatio(raw string) *Error {
+	return paramError.NewError(0x08, Error{
+		text: fmt.Sprintf("分配比例「%s」无效：比例不能为负数，且不能全为0", raw),
+		info: fmt.Sprintf("ratio=(%s)", raw),
+	})
+}
+
+// UnknownParamName - the function has no param of given name, e.g. （计算：不存在 == 1）
+func UnknownParamName(name string) *Error {
+	return paramError.NewError(0x09, Error{
+		text: fmt.Sprintf("此方法未定义名为「%s」的参数", name),
+		info: fmt.Sprintf("name=(%s)", name),
+	})
+}
+
+// DuplicateParamName - the param is given more than once (by position or by name)
+func DuplicateParamName(name string) *Error {
+	return paramError.NewError(0x0A, Error{
+		text: fmt.Sprintf("参数「%s」被重复输入", name),
+		info: fmt.Sprintf("name=(%s)", name),
+	})
+}
+
+// MissingParam - the param without default value is not given
+func MissingParam(name string) *Error {
+	return paramError.NewError(0x0B, Error{
+		text: fmt.Sprintf("缺少参数「%s」", name),
+		info: fmt.Sprintf("name=(%s)", name),
+	})
+}
+
+// NamedParamNotSupported - builtin functions only accept positional params
+func NamedParamNotSupported(name string) *Error {
+	return paramError.NewError(0x0C, Error{
+		text: fmt.Sprintf("内置方法不支持按名称输入参数「%s」", name),
+		info: fmt.Sprintf("name=(%s)", name),
+	})
+}
+
+// DateOffsetOutOfRange - e.g. 日期之（加工作日：2000000000）
+func DateOffsetOutOfRange(n int, max int) *Error {
+	return paramError.NewError(0x0D, Error{
+		text: fmt.Sprintf("日期的增减量 %d 超出范围：须在 ±%d 之内", n, max),
+		info: fmt.Sprintf("offset=(%d) max=(%d)", n, max),
+	})
+}
+
+// PartsOutOfRange - e.g. ¥1之（平分：1000000000）
+func PartsOutOfRange(n int, max int) *Error {
+	return paramError.NewError(0x0E, Error{
+		text: fmt.Sprintf("份数 %d 超出范围：须在 1 至 %d 之间", n, max),
+		info: fmt.Sprintf("parts=(%d) max=(%d)", n, max),
+	})
+}
@@ -1,5 +1,7 @@
 package error
 
+import "fmt"
+
 // InvalidSyntax -
 func InvalidSyntax() *Error {
 	return syntaxError.NewError(0x50, Error{
@@ -67,6 +69,16 @@ func UnexpectedEOF() *Error {
 	})
 }
 
+// NumeralAsIdentifier - a Chinese numeral (e.g. 十，五) is always parsed as a number,
+// thus it could not be used as an identifier directly.
+// e.g. 令十为10
+func NumeralAsIdentifier(name string) *Error {
+	return syntaxError.NewError(0x56, Error{
+		text: fmt.Sprintf("「%s」为数字，不能用作标识符；可改写为「·%s·」", name, name),
+		info: fmt.Sprintf("cursor=(peek) name=(%s)", name),
+	})
+}
+
 // MixArrayHashMap - inside 【】, an array element is mixed with a hashmap element
 // e.g. 【100，100 == 200，300】
 func MixArrayHashMap() *Error {
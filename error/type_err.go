@@ -15,6 +15,11 @@ var typeNameMap = map[string]string{
 	"array":    "元组",
 	"hashmap":  "列表",
 	"id":       "标识",
+	"date":     "日期",
+	"time":     "时刻",
+	"duration": "时长",
+	"money":    "金额",
+	"fraction": "分数",
 }
 
 // InvalidExprType -
@@ -84,3 +89,11 @@ func InvalidCompareRType(assertType ...string) *Error {
 		text: fmt.Sprintf("被比较值的类型应为%s", strings.Join(labels, "、")),
 	})
 }
+
+// InvalidHostValueType - the value could not be converted between Go and Zn
+func InvalidHostValueType(typeName string) *Error {
+	return typeError.NewError(0x06, Error{
+		text: fmt.Sprintf("无法转换「%s」类型的值", typeName),
+		info: fmt.Sprintf("type=(%s)", typeName),
+	})
+}
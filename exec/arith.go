@@ -9,12 +9,57 @@ import (
 
 // Arith - arithmetic calculation (including + - * /) instance
 type Arith struct {
+	// precision - number of significant digits of division (and square root) results
 	precision int
+	// rounding - the rounding mode of division results, and the default mode of Round()
+	rounding RoundingMode
 }
 
-// NewArith -
+// RoundingMode - decides how to deal with the dropped digits when rounding a decimal
+type RoundingMode uint8
+
+// declare rounding modes
+const (
+	// RoundHalfUp - (default mode) round half away from zero (四舍五入), e.g. 2.5 -> 3, -2.5 -> -3
+	RoundHalfUp RoundingMode = 1
+	// RoundHalfEven - round half to even (四舍六入五成双, a.k.a. banker's rounding), e.g. 2.5 -> 2, 3.5 -> 4
+	RoundHalfEven RoundingMode = 2
+	// RoundFloor - round towards negative infinity (向下), e.g. 2.7 -> 2, -2.1 -> -3
+	RoundFloor RoundingMode = 3
+	// RoundCeiling - round towards positive infinity (向上), e.g. 2.1 -> 3, -2.7 -> -2
+	RoundCeiling RoundingMode = 4
+	// RoundTruncate - drop extra digits directly (截断), e.g. 2.7 -> 2, -2.7 -> -2
+	RoundTruncate RoundingMode = 5
+)
+
+// RoundingModeNames - the names of rounding modes used in Zn code
+var RoundingModeNames = map[string]RoundingMode{
+	"四舍五入":    RoundHalfUp,
+	"四舍六入五成双": RoundHalfEven,
+	"银行家舍入":   RoundHalfEven,
+	"向下":      RoundFloor,
+	"向上":      RoundCeiling,
+	"截断":      RoundTruncate,
+}
+
+// NewArith - create an Arith with round-half-up mode
 func NewArith(precision int) *Arith {
-	return &Arith{precision}
+	return &Arith{precision, RoundHalfUp}
+}
+
+// NewArithWithRounding -
+func NewArithWithRounding(precision int, rounding RoundingMode) *Arith {
+	return &Arith{precision, rounding}
+}
+
+// GetPrecision -
+func (ai *Arith) GetPrecision() int {
+	return ai.precision
+}
+
+// GetRoundingMode -
+func (ai *Arith) GetRoundingMode() RoundingMode {
+	return ai.rounding
 }
 
 // Add - A + B + C + D + ... = ?
@@ -82,6 +127,12 @@ func (ai *Arith) Div(decimal1 *ZnDecimal, others ...*ZnDecimal) (*ZnDecimal, *er
 		if item.co.Cmp(num0) == 0 {
 			return nil, error.ArithDivZeroError()
 		}
+		// item would be adjusted below, copy it to keep the original value unchanged
+		item = copyZnDecimal(item)
+		// do division on absolute values, then apply the sign at last
+		negative := result.co.Sign() != item.co.Sign()
+		result.co.Abs(result.co)
+		item.co.Abs(item.co)
 		adjust := 0
 		// adjust bits
 		// C1 < C2
@@ -112,12 +163,7 @@ func (ai *Arith) Div(decimal1 *ZnDecimal, others ...*ZnDecimal) (*ZnDecimal, *er
 		if adjust < 0 {
 			precFactor = ai.precision
 		}
-		var exp10x *big.Int
-		if ai.precision >= 18 {
-			exp10x = new(big.Int).Exp(num10, num10, big.NewInt(int64(precFactor-1)))
-		} else {
-			exp10x = big.NewInt(int64(math.Pow10(precFactor)))
-		}
+		var exp10x = new(big.Int).Exp(num10, big.NewInt(int64(precFactor)), nil)
 
 		// do div
 		var mul10x = exp10x.Mul(result.co, exp10x)
@@ -125,17 +171,280 @@ func (ai *Arith) Div(decimal1 *ZnDecimal, others ...*ZnDecimal) (*ZnDecimal, *er
 		var xq, _ = result.co.DivMod(mul10x, item.co, xr) // don't use QuoRem here!
 
 		// rounding
-		if xr.Mul(xr, big.NewInt(2)).Cmp(item.co) > 0 {
-			xq = xq.Add(xq, big.NewInt(1))
+		if xr.Sign() != 0 {
+			sign := 1
+			if negative {
+				sign = -1
+			}
+			cmpHalf := xr.Mul(xr, big.NewInt(2)).Cmp(item.co)
+			if shouldRoundAway(ai.rounding, sign, cmpHalf, xq.Bit(0) == 1) {
+				xq = xq.Add(xq, big.NewInt(1))
+			}
 		}
 
 		// get final result
+		if negative {
+			xq.Neg(xq)
+		}
 		result.co = xq
 		result.exp = result.exp - item.exp - adjust - precFactor
 	}
 	return result, nil
 }
 
+// Mod - A % B = ?, the sign of result is same as the dividend (A)
+// when B = 0, an ArithDivZeroError will be yield
+func (ai *Arith) Mod(decimal1 *ZnDecimal, decimal2 *ZnDecimal) (*ZnDecimal, *error.Error) {
+	if decimal2.co.Sign() == 0 {
+		return nil, error.ArithDivZeroError()
+	}
+	var result = copyZnDecimal(decimal1)
+	r1, r2 := rescalePair(decimal1, decimal2)
+	result.co.Rem(r1.co, r2.co)
+	result.exp = r1.exp
+	return result, nil
+}
+
+// Neg - -A = ?, ZnDecimal value will be copied
+func (ai *Arith) Neg(decimal1 *ZnDecimal) *ZnDecimal {
+	var result = copyZnDecimal(decimal1)
+	result.co.Neg(result.co)
+	return result
+}
+
+// getRoundingModeName - get the (first) name of a rounding mode
+func getRoundingModeName(mode RoundingMode) string {
+	names := map[RoundingMode]string{
+		RoundHalfUp:   "四舍五入",
+		RoundHalfEven: "四舍六入五成双",
+		RoundFloor:    "向下",
+		RoundCeiling:  "向上",
+		RoundTruncate: "截断",
+	}
+	return names[mode]
+}
+
+// Round - round the decimal to N places (N could be negative, e.g. N = -2 means rounding to hundreds)
+// by given mode; the result always has exactly N places, e.g. Round(12, 2) = 12.00,
+// except that zero is always displayed as 0 when N < 0, e.g. Round(0.001, -2) = 0
+func (ai *Arith) Round(decimal1 *ZnDecimal, places int, mode RoundingMode) (*ZnDecimal, *error.Error) {
+	if err := checkPlaces(places); err != nil {
+		return nil, err
+	}
+	var result = copyZnDecimal(decimal1)
+	defer normalizeZero(result)
+	var targetExp = -places
+
+	// no digits to drop, only add tail zeros
+	if result.exp >= targetExp {
+		if result.co.Sign() == 0 {
+			result.exp = targetExp
+			return result, nil
+		}
+		factor, err := pow10(result.exp - targetExp)
+		if err != nil {
+			return nil, err
+		}
+		result.co.Mul(result.co, factor)
+		result.exp = targetExp
+		return result, nil
+	}
+
+	// drop extra digits (i.e. truncate), then adjust it by rounding mode
+	var cmpHalf int
+	if drop := targetExp - result.exp; drop > len(result.co.String()) {
+		// all digits are dropped and they're less than half of the last place,
+		// thus there's no need to expand 10^drop
+		cmpHalf = -1
+		result.co.SetInt64(0)
+	} else {
+		factor, _ := pow10(drop)
+		rem := new(big.Int)
+		result.co.QuoRem(result.co, factor, rem)
+		if rem.Sign() == 0 {
+			result.exp = targetExp
+			return result, nil
+		}
+		// compare the dropped part with half of the last place
+		rem.Abs(rem)
+		cmpHalf = rem.Mul(rem, big.NewInt(2)).Cmp(factor)
+	}
+	result.exp = targetExp
+	if decimal1.co.Sign() == 0 {
+		return result, nil
+	}
+
+	sign := decimal1.co.Sign()
+	if shouldRoundAway(mode, sign, cmpHalf, result.co.Bit(0) == 1) {
+		result.co.Add(result.co, big.NewInt(int64(sign)))
+	}
+	return result, nil
+}
+
+// maxDigits - the max number of digits that a decimal could be expanded to (e.g. by 乘方，四舍五入)
+// at once, so that a single calculation won't hang the execution or exhaust the memory.
+const maxDigits = 100000
+
+// pow10 - 10^n, where n should not be greater than maxDigits
+func pow10(n int) (*big.Int, *error.Error) {
+	if n > maxDigits {
+		return nil, error.DigitsOutOfRange(maxDigits)
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil), nil
+}
+
+// checkPlaces - the number of decimal places should be within ±maxDigits
+func checkPlaces(places int) *error.Error {
+	if places > maxDigits || places < -maxDigits {
+		return error.PlacesOutOfRange(places, maxDigits)
+	}
+	return nil
+}
+
+// normalizeZero - for zero with positive exponent (e.g. 0 × 10^2, which is displayed as 000),
+// reset its exponent to 0
+func normalizeZero(zd *ZnDecimal) {
+	if zd.co.Sign() == 0 && zd.exp > 0 {
+		zd.exp = 0
+	}
+}
+
+// shouldRoundAway - when some (non-zero) digits are dropped, decide whether the truncated
+// value should be added by 1 (on its last place) away from zero.
+// cmpHalf: the dropped part compared with half of the last place (-1, 0, 1)
+// odd: if the last place of truncated value is odd
+func shouldRoundAway(mode RoundingMode, sign int, cmpHalf int, odd bool) bool {
+	switch mode {
+	case RoundFloor:
+		return sign < 0
+	case RoundCeiling:
+		return sign > 0
+	case RoundHalfUp:
+		return cmpHalf >= 0
+	case RoundHalfEven:
+		return cmpHalf > 0 || (cmpHalf == 0 && odd)
+	}
+	return false
+}
+
+// Abs - |A| = ?
+func (ai *Arith) Abs(decimal1 *ZnDecimal) *ZnDecimal {
+	var result = copyZnDecimal(decimal1)
+	result.co.Abs(result.co)
+	return result
+}
+
+// IntDiv - A 整除 B = ?, the quotient is truncated towards zero, so that
+// A = IntDiv(A, B) * B + Mod(A, B)
+func (ai *Arith) IntDiv(decimal1 *ZnDecimal, decimal2 *ZnDecimal) (*ZnDecimal, *error.Error) {
+	if decimal2.co.Sign() == 0 {
+		return nil, error.ArithDivZeroError()
+	}
+	var result = copyZnDecimal(decimal1)
+	r1, r2 := rescalePair(decimal1, decimal2)
+	result.co.Quo(r1.co, r2.co)
+	result.exp = 0
+	return result, nil
+}
+
+// Pow - A ^ N = ?, where N is an integer. When N < 0, the result is calculated by 1 / (A ^ -N),
+// thus it's limited by the precision as well.
+func (ai *Arith) Pow(decimal1 *ZnDecimal, n int) (*ZnDecimal, *error.Error) {
+	var result = copyZnDecimal(decimal1)
+	absN := n
+	if n < 0 {
+		absN = -n
+	}
+	// check the size of result before calculation, e.g. 2^100000000000 has too many digits;
+	// while 0^N and (±1)^N are always small.
+	if bitLen := result.co.BitLen(); bitLen > 1 {
+		if absN > maxDigits*4 || (bitLen-1)*absN > maxDigits*4 {
+			return nil, error.DigitsOutOfRange(maxDigits)
+		}
+	}
+	// the exponent should not overflow
+	if absExp := absInt(result.exp); absExp > 0 && absN > math.MaxInt32/absExp {
+		return nil, error.DigitsOutOfRange(maxDigits)
+	}
+	result.co.Exp(result.co, big.NewInt(int64(absN)), nil)
+	result.exp = result.exp * absN
+	if n >= 0 {
+		return result, nil
+	}
+	if result.co.Sign() == 0 {
+		return nil, error.ArithDivZeroError()
+	}
+	return ai.Div(NewZnDecimalFromInt(1, 0), result)
+}
+
+// Sqrt - √A = ?, the result is rounded to the same significant digits as division.
+// The root is calculated by integer square root, so all digits are exact.
+func (ai *Arith) Sqrt(decimal1 *ZnDecimal) (*ZnDecimal, *error.Error) {
+	var result = copyZnDecimal(decimal1)
+	switch decimal1.co.Sign() {
+	case -1:
+		return nil, error.ArithSqrtNegativeError()
+	case 0:
+		return result, nil
+	}
+	// scale the coefficient so that it has enough digits (at least 2*(precision+1)),
+	// and the exponent is even
+	digits := len(decimal1.co.String())
+	scale := 0
+	if need := 2 * (ai.precision + 1); digits < need {
+		scale = need - digits
+	}
+	if (decimal1.exp-scale)%2 != 0 {
+		scale = scale + 1
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	result.co.Mul(result.co, factor)
+	scaled := new(big.Int).Set(result.co)
+	result.co.Sqrt(result.co)
+	result.exp = (decimal1.exp - scale) / 2
+	// if the root is not exact, append a "sticky" digit 1 to mark that there're
+	// still non-zero digits remaining, so that it could be rounded correctly.
+	if new(big.Int).Mul(result.co, result.co).Cmp(scaled) != 0 {
+		result.co.Mul(result.co, big.NewInt(10))
+		result.co.Add(result.co, big.NewInt(1))
+		result.exp = result.exp - 1
+	}
+
+	// drop extra digits
+	if drop := len(result.co.String()) - ai.precision; drop > 0 {
+		return ai.Round(result, -(result.exp + drop), ai.rounding)
+	}
+	return result, nil
+}
+
+// Cmp - compare A and B, returns -1 if A < B, 0 if A = B and 1 if A > B
+func (ai *Arith) Cmp(decimal1 *ZnDecimal, decimal2 *ZnDecimal) int {
+	r1, r2 := rescalePair(decimal1, decimal2)
+	return r1.co.Cmp(r2.co)
+}
+
+// Max - get the maximum value of A, B, C, ...
+func (ai *Arith) Max(decimal1 *ZnDecimal, others ...*ZnDecimal) *ZnDecimal {
+	var result = decimal1
+	for _, item := range others {
+		if ai.Cmp(item, result) > 0 {
+			result = item
+		}
+	}
+	return copyZnDecimal(result)
+}
+
+// Min - get the minimum value of A, B, C, ...
+func (ai *Arith) Min(decimal1 *ZnDecimal, others ...*ZnDecimal) *ZnDecimal {
+	var result = decimal1
+	for _, item := range others {
+		if ai.Cmp(item, result) < 0 {
+			result = item
+		}
+	}
+	return copyZnDecimal(result)
+}
+
 //// arith helper
 
 // rescalePair - make exps to be same
@@ -168,6 +477,20 @@ func rescalePair(d1 *ZnDecimal, d2 *ZnDecimal) (*ZnDecimal, *ZnDecimal) {
 	return d1, nD2
 }
 
+// trimTailZeros - remove tail zeros of the decimal part in place, e.g. 1.5000000 -> 1.5, 1200 -> 1200
+func trimTailZeros(zd *ZnDecimal) {
+	num10 := big.NewInt(10)
+	rem := new(big.Int)
+	for zd.exp < 0 {
+		quo, _ := new(big.Int).QuoRem(zd.co, num10, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		zd.co = quo
+		zd.exp++
+	}
+}
+
 // copyDecimal - duplicate deicmal value to a new variable
 func copyZnDecimal(old *ZnDecimal) *ZnDecimal {
 	result, _ := duplicateValue(old).(*ZnDecimal)
@@ -0,0 +1,203 @@
+package exec
+
+import (
+	"testing"
+)
+
+func TestArith_Round(t *testing.T) {
+	cases := []struct {
+		input  string
+		places int
+		mode   RoundingMode
+		expect string
+	}{
+		{"2.345", 2, RoundHalfUp, "2.35"},
+		{"-2.345", 2, RoundHalfUp, "-2.35"},
+		{"2.344", 2, RoundHalfUp, "2.34"},
+		{"2.345", 2, RoundHalfEven, "2.34"},
+		{"2.355", 2, RoundHalfEven, "2.36"},
+		{"-2.345", 2, RoundHalfEven, "-2.34"},
+		{"2.3451", 2, RoundHalfEven, "2.35"},
+		{"2.349", 2, RoundFloor, "2.34"},
+		{"-2.341", 2, RoundFloor, "-2.35"},
+		{"2.341", 2, RoundCeiling, "2.35"},
+		{"-2.349", 2, RoundCeiling, "-2.34"},
+		{"2.349", 2, RoundTruncate, "2.34"},
+		{"-2.349", 2, RoundTruncate, "-2.34"},
+		{"12", 2, RoundHalfUp, "12.00"},
+		{"2.5", 0, RoundHalfUp, "3"},
+		{"0.5", 0, RoundHalfEven, "0"},
+		{"1234.5678", -2, RoundHalfUp, "1200"},
+		{"1250", -2, RoundHalfEven, "1200"},
+		{"0.0001", 2, RoundCeiling, "0.01"},
+		{"-3.5", -1, RoundHalfUp, "0"},
+		{"0.001", -2, RoundHalfUp, "0"},
+		{"0", -3, RoundHalfUp, "0"},
+		{"0.00", 1, RoundHalfUp, "0.0"},
+		{"1E-200000", 2, RoundHalfUp, "0.00"},
+		{"1E-200000", 2, RoundCeiling, "0.01"},
+		{"-1E-200000", 0, RoundFloor, "-1"},
+		{"0E200000", 2, RoundHalfUp, "0.00"},
+	}
+
+	arith := NewArith(defaultPrecision)
+	for _, tt := range cases {
+		d, _ := NewZnDecimal(tt.input)
+		original := d.String()
+		got, err := arith.Round(d, tt.places, tt.mode)
+		if err != nil {
+			t.Errorf("Round(%s, %d, %d) expect no error, got %s", tt.input, tt.places, tt.mode, err.Error())
+			continue
+		}
+		if got.String() != tt.expect {
+			t.Errorf("Round(%s, %d, %d) expect -> %s, got -> %s", tt.input, tt.places, tt.mode, tt.expect, got.String())
+		}
+		// original value should not be changed
+		if d.String() != original {
+			t.Errorf("Round() should not change the original value %s, got %s", original, d.String())
+		}
+	}
+}
+
+func TestArith_PowAndSqrt(t *testing.T) {
+	cases := []struct {
+		name      string
+		precision int
+		fn        func(ai *Arith, d *ZnDecimal) (*ZnDecimal, error)
+		input     string
+		expect    string
+	}{
+		{"pow", 8, pow(3), "1.5", "3.375"},
+		{"pow zero", 8, pow(0), "12.5", "1"},
+		{"pow negative exp", 8, pow(-2), "2", "0.25000000"},
+		{"pow negative base", 8, pow(3), "-2", "-8"},
+		{"pow of one with huge exp", 8, pow(100000000000), "1", "1"},
+		{"pow of minus one with huge exp", 8, pow(100000000001), "-1", "-1"},
+		{"sqrt", 8, sqrt, "2", "1.4142136"},
+		{"sqrt of perfect square", 8, sqrt, "16", "4.0000000"},
+		{"sqrt of small number", 8, sqrt, "0.0001", "0.010000000"},
+		{"sqrt with high precision", 30, sqrt, "2", "1.41421356237309504880168872421"},
+		{"sqrt of zero", 8, sqrt, "0", "0"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := NewZnDecimal(tt.input)
+			got, err := tt.fn(NewArith(tt.precision), d)
+			if err != nil {
+				t.Errorf("expect no error, got %s", err.Error())
+				return
+			}
+			if got.plainString() != tt.expect {
+				t.Errorf("expect -> %s, got -> %s", tt.expect, got.plainString())
+			}
+		})
+	}
+}
+
+func TestArith_Errors(t *testing.T) {
+	arith := NewArith(defaultPrecision)
+	if _, err := arith.Sqrt(NewZnDecimalFromInt(-4, 0)); err == nil || err.GetCode() != 0x2604 {
+		t.Errorf("Sqrt(-4) should return error 2604, got %v", err)
+	}
+	if _, err := arith.Pow(NewZnDecimalFromInt(0, 0), -1); err == nil || err.GetCode() != 0x2601 {
+		t.Errorf("Pow(0, -1) should return error 2601, got %v", err)
+	}
+	if _, err := arith.IntDiv(NewZnDecimalFromInt(1, 0), NewZnDecimalFromInt(0, 0)); err == nil || err.GetCode() != 0x2601 {
+		t.Errorf("IntDiv(1, 0) should return error 2601, got %v", err)
+	}
+	// too many digits
+	if _, err := arith.Pow(NewZnDecimalFromInt(2, 0), 100000000000); err == nil || err.GetCode() != 0x2609 {
+		t.Errorf("Pow(2, 100000000000) should return error 2609, got %v", err)
+	}
+	if _, err := arith.Pow(NewZnDecimalFromInt(1, -1), 100000000000); err == nil || err.GetCode() != 0x2609 {
+		t.Errorf("Pow(0.1, 100000000000) should return error 2609, got %v", err)
+	}
+	if _, err := arith.Round(NewZnDecimalFromInt(1, 200000), 0, RoundHalfUp); err == nil || err.GetCode() != 0x2609 {
+		t.Errorf("Round(1E200000, 0) should return error 2609, got %v", err)
+	}
+	if _, err := arith.Round(NewZnDecimalFromInt(1, 0), 100000000000, RoundHalfUp); err == nil || err.GetCode() != 0x260A {
+		t.Errorf("Round(1, 100000000000) should return error 260A, got %v", err)
+	}
+}
+
+func TestArith_CompareHelpers(t *testing.T) {
+	arith := NewArith(defaultPrecision)
+	a, _ := NewZnDecimal("1.50")
+	b, _ := NewZnDecimal("1.5")
+	c, _ := NewZnDecimal("-7")
+
+	if arith.Cmp(a, b) != 0 || arith.Cmp(a, c) != 1 || arith.Cmp(c, b) != -1 {
+		t.Errorf("Cmp() result not match")
+	}
+	if got := arith.Max(c, a, NewZnDecimalFromInt(2, 0)).String(); got != "2" {
+		t.Errorf("Max() expect -> 2, got -> %s", got)
+	}
+	if got := arith.Min(a, c, b).String(); got != "-7" {
+		t.Errorf("Min() expect -> -7, got -> %s", got)
+	}
+	q, _ := arith.IntDiv(NewZnDecimalFromInt(-7, 0), NewZnDecimalFromInt(2, 0))
+	if q.String() != "-3" {
+		t.Errorf("IntDiv(-7, 2) expect -> -3, got -> %s", q.String())
+	}
+	if got := arith.Abs(c).String(); got != "7" {
+		t.Errorf("Abs(-7) expect -> 7, got -> %s", got)
+	}
+}
+
+func TestArith_DivHighPrecision(t *testing.T) {
+	d, err := NewArith(20).Div(NewZnDecimalFromInt(1, 0), NewZnDecimalFromInt(3, 0))
+	if err != nil {
+		t.Errorf("expect no error, got %s", err.Error())
+		return
+	}
+	if d.plainString() != "0.33333333333333333333" {
+		t.Errorf("expect -> 0.33333333333333333333, got -> %s", d.plainString())
+	}
+}
+
+func TestArith_DivRounding(t *testing.T) {
+	cases := []struct {
+		mode   RoundingMode
+		a      int
+		b      int
+		expect string
+	}{
+		{RoundHalfUp, 1, 8, "0.13"},
+		{RoundHalfUp, -1, 8, "-0.13"},
+		{RoundHalfEven, 1, 8, "0.12"},
+		{RoundHalfEven, 3, 8, "0.38"},
+		{RoundFloor, -2, 3, "-0.67"},
+		{RoundFloor, 2, 3, "0.66"},
+		{RoundCeiling, 1, 3, "0.34"},
+		{RoundTruncate, -2, 3, "-0.66"},
+	}
+	for _, tt := range cases {
+		d, err := NewArithWithRounding(2, tt.mode).Div(NewZnDecimalFromInt(tt.a, 0), NewZnDecimalFromInt(tt.b, 0))
+		if err != nil {
+			t.Errorf("expect no error, got %s", err.Error())
+			continue
+		}
+		if d.String() != tt.expect {
+			t.Errorf("Div(%d, %d) with mode %d expect -> %s, got -> %s", tt.a, tt.b, tt.mode, tt.expect, d.String())
+		}
+	}
+}
+
+func pow(n int) func(ai *Arith, d *ZnDecimal) (*ZnDecimal, error) {
+	return func(ai *Arith, d *ZnDecimal) (*ZnDecimal, error) {
+		r, err := ai.Pow(d, n)
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+}
+
+func sqrt(ai *Arith, d *ZnDecimal) (*ZnDecimal, error) {
+	r, err := ai.Sqrt(d)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
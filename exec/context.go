@@ -1,6 +1,13 @@
 package exec
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/reg0007/Zn/debug"
 	"github.com/reg0007/Zn/error"
 	"github.com/reg0007/Zn/lex"
@@ -8,14 +15,59 @@ import (
 )
 
 // Context - GLOBAL execution context, usually create only once in one program.
+//
+// Each Context owns its globals, registered values, imported modules and arith settings,
+// thus separate Contexts could execute code on separate goroutines without data races.
+// However, one Context is NOT safe for concurrent use.
 type Context struct {
 	globals map[string]ZnValue
 	arith   *Arith
+	// classRefs - native classes registered by host program (see RegisterClass)
+	classRefs map[string]*ClassRef
+	// modules - imported modules (cached by absolute file path) so that
+	// one file will be executed only once even it's imported many times.
+	modules map[string]*ZnModule
+	// importStack - paths of modules being imported, for detecting circular imports
+	importStack []string
 	// a seperate map to store inner debug data
 	// usage: call （__probe：「tagName」，variable）
 	// it will record all logs (including variable value, curernt scope, etc.)
 	// the value is deep-copied so don't worry - the value logged won't be changed
 	_probe *debug.Probe
+	// limits - execution limits (see Limits)
+	limits Limits
+	// runtime states for checking limits, reset on every ExecuteCode call
+	goCtx     context.Context
+	stmtCount int
+	callDepth int
+	// callStack - frames of (non-native) closures being executed
+	callStack []callFrame
+	// currentRoot - RootScope of the file being executed (see setCurrentLine)
+	currentRoot *RootScope
+	// clock - get current time for 现在 & 今天 (see SetClock)
+	clock func() time.Time
+	// location - default timezone of 时刻 values
+	location *time.Location
+}
+
+// callFrame - records where a closure is called from, so that the execution
+// position could be restored after the closure returns.
+type callFrame struct {
+	name       string
+	callerRoot *RootScope
+	callerLine int
+}
+
+// Limits - execution limits of a Context. Once a limit is exceeded, the execution
+// will be aborted with an error of LimitErrorClass, which could NOT be caught by 捕获 block.
+// For all fields, 0 means no limit.
+type Limits struct {
+	// MaxStatements - max number of statements to be evaluated
+	MaxStatements int
+	// MaxCallDepth - max depth of (nested) function, method & getter calls
+	MaxCallDepth int
+	// MaxDuration - max wall time of one execution
+	MaxDuration time.Duration
 }
 
 const defaultPrecision = 8
@@ -34,14 +86,117 @@ type Result struct {
 // NewContext - create new Zn Context for furthur execution
 func NewContext() *Context {
 	return &Context{
-		globals: predefinedValues,
-		arith:   NewArith(defaultPrecision),
-		_probe:  debug.NewProbe(),
+		globals:   newPredefinedValues(),
+		arith:     NewArith(defaultPrecision),
+		classRefs: map[string]*ClassRef{},
+		modules:   map[string]*ZnModule{},
+		_probe:    debug.NewProbe(),
+		goCtx:     context.Background(),
+		clock:     time.Now,
+		location:  time.Local,
+	}
+}
+
+// SetPrecision - set default precision (number of significant digits) of division results,
+// which must be positive. It could be overridden inside Zn code by 此之（精度：N）.
+func (ctx *Context) SetPrecision(precision int) *error.Error {
+	if precision <= 0 {
+		return error.InvalidPrecision(precision)
+	}
+	ctx.arith = NewArithWithRounding(precision, ctx.arith.rounding)
+	return nil
+}
+
+// SetRoundingMode - set default rounding mode of division results and 四舍五入 function.
+// It could be overridden inside Zn code by 此之（舍入模式：「…」）.
+func (ctx *Context) SetRoundingMode(mode RoundingMode) *error.Error {
+	if mode < RoundHalfUp || mode > RoundTruncate {
+		return error.InvalidRoundingMode(strconv.Itoa(int(mode)))
+	}
+	ctx.arith = NewArithWithRounding(ctx.arith.precision, mode)
+	return nil
+}
+
+// SetClock - set the clock to get current time (used by 现在 & 今天), which is time.Now by default.
+// It's useful to get a fixed time for testing.
+func (ctx *Context) SetClock(clock func() time.Time) {
+	ctx.clock = clock
+}
+
+// SetLocation - set default timezone of 时刻 values (e.g. parsed from strings, or got from 现在),
+// which is time.Local by default.
+func (ctx *Context) SetLocation(loc *time.Location) {
+	ctx.location = loc
+}
+
+// now - current time under the default timezone
+func (ctx *Context) now() time.Time {
+	return ctx.clock().In(ctx.location)
+}
+
+// SetLimits - set execution limits for furthur execution
+func (ctx *Context) SetLimits(limits Limits) {
+	ctx.limits = limits
+}
+
+// GetGlobals - get all global values (i.e. predefined & registered values)
+func (ctx *Context) GetGlobals() map[string]ZnValue {
+	globals := map[string]ZnValue{}
+	for name, value := range ctx.globals {
+		globals[name] = value
 	}
+	return globals
+}
+
+// RegisterFunction - expose a Go function to Zn code as a global function.
+// Like predefined functions (e.g. 显示), it could not be redeclared by Zn code.
+func (ctx *Context) RegisterFunction(name string, executor FuncExecutor) *error.Error {
+	return ctx.RegisterConstant(name, NewZnNativeFunction(name, executor))
+}
+
+// RegisterConstant - expose a value to Zn code as a global constant.
+// Use ToZnValue() to convert a Go value first.
+func (ctx *Context) RegisterConstant(name string, value ZnValue) *error.Error {
+	if _, ok := ctx.globals[name]; ok {
+		return error.NameRedeclared(name)
+	}
+	ctx.globals[name] = value
+	return nil
+}
+
+// RegisterClass - expose a native class (see NewNativeClassRef) to Zn code, so that
+// objects could be created by `令 对象 成为 类名：参数1，参数2，...`.
+func (ctx *Context) RegisterClass(classRef *ClassRef) *error.Error {
+	name := classRef.Name
+	if _, ok := ctx.classRefs[name]; ok {
+		return error.NameRedeclared(name)
+	}
+	ctx.classRefs[name] = classRef
+	return nil
 }
 
 // ExecuteCode - execute program from input Zn code (whether from file or REPL)
 func (ctx *Context) ExecuteCode(in *lex.InputStream, scope *RootScope) Result {
+	return ctx.ExecuteCodeWithContext(context.Background(), in, scope)
+}
+
+// ExecuteCodeWithContext - execute program from input Zn code. The execution will be aborted
+// when goCtx is cancelled (or its deadline exceeds), as well as the limits are exceeded.
+func (ctx *Context) ExecuteCodeWithContext(goCtx context.Context, in *lex.InputStream, scope *RootScope) Result {
+	if ctx.limits.MaxDuration > 0 {
+		var cancel context.CancelFunc
+		goCtx, cancel = context.WithTimeout(goCtx, ctx.limits.MaxDuration)
+		defer cancel()
+	}
+	ctx.goCtx = goCtx
+	ctx.stmtCount = 0
+	ctx.callDepth = 0
+	ctx.callStack = []callFrame{}
+	ctx.currentRoot = scope
+	defer func() {
+		ctx.goCtx = context.Background()
+	}()
+
 	l := lex.NewLexer(in)
 	p := syntax.NewParser(l)
 	// start
@@ -63,6 +218,155 @@ func (ctx *Context) ExecuteCode(in *lex.InputStream, scope *RootScope) Result {
 	return Result{false, scope.GetLastValue(), nil}
 }
 
+// checkStatementLimits - called before evaluating each statement
+func (ctx *Context) checkStatementLimits() *error.Error {
+	switch ctx.goCtx.Err() {
+	case context.Canceled:
+		return error.ExecutionCancelled()
+	case context.DeadlineExceeded:
+		return error.ExecutionTimeout()
+	}
+	ctx.stmtCount++
+	if ctx.limits.MaxStatements > 0 && ctx.stmtCount > ctx.limits.MaxStatements {
+		return error.MaxStatementsExceeded(ctx.limits.MaxStatements)
+	}
+	return nil
+}
+
+// enterCall - increase call depth and push call stack before executing a closure.
+// If no error returns, exitCall() MUST be called after the execution.
+func (ctx *Context) enterCall(cr *ClosureRef) *error.Error {
+	if ctx.limits.MaxCallDepth > 0 && ctx.callDepth >= ctx.limits.MaxCallDepth {
+		return error.MaxCallDepthExceeded(ctx.limits.MaxCallDepth)
+	}
+	ctx.callDepth++
+	// native functions are not traced
+	if cr.lexScope != nil && ctx.currentRoot != nil {
+		ctx.callStack = append(ctx.callStack, callFrame{
+			name:       cr.Name,
+			callerRoot: ctx.currentRoot,
+			callerLine: ctx.currentRoot.currentLine,
+		})
+	}
+	return nil
+}
+
+// exitCall - decrease call depth and pop call stack after executing a closure,
+// then restore the execution position of the caller.
+func (ctx *Context) exitCall(cr *ClosureRef) {
+	ctx.callDepth--
+	if cr.lexScope != nil && len(ctx.callStack) > 0 {
+		frame := ctx.callStack[len(ctx.callStack)-1]
+		ctx.callStack = ctx.callStack[:len(ctx.callStack)-1]
+
+		frame.callerRoot.SetCurrentLine(frame.callerLine)
+		ctx.currentRoot = frame.callerRoot
+	}
+}
+
+// setCurrentLine - mark current execution position (file & line)
+func (ctx *Context) setCurrentLine(scope Scope, line int) {
+	root := scope.GetRoot()
+	root.SetCurrentLine(line)
+	ctx.currentRoot = root
+}
+
+// GetCallStack - get current call stack (innermost frame first). It's useful for
+// native functions to find out where they're called from.
+func (ctx *Context) GetCallStack() []error.StackFrame {
+	frames := []error.StackFrame{}
+	if ctx.currentRoot == nil {
+		return frames
+	}
+	root, line := ctx.currentRoot, ctx.currentRoot.currentLine
+	for i := len(ctx.callStack) - 1; i >= 0; i-- {
+		frame := ctx.callStack[i]
+		frames = append(frames, error.StackFrame{
+			Name:    frame.name,
+			File:    root.file,
+			LineNum: line,
+		})
+		root, line = frame.callerRoot, frame.callerLine
+	}
+	return append(frames, error.StackFrame{
+		File:    root.file,
+		LineNum: line,
+	})
+}
+
+// traceError - add call stack & line info to the error raised inside a closure
+func (ctx *Context) traceError(err *error.Error) {
+	if err.GetErrorClass() == error.BreakErrorClass || ctx.currentRoot == nil {
+		return
+	}
+	if err.GetStack() == nil && len(ctx.callStack) > 0 {
+		err.SetStack(ctx.GetCallStack())
+	}
+	wrapError(ctx, ctx.currentRoot, err)
+}
+
+// importModule - load a module from file, and execute it under its own RootScope.
+// NOTICE: path should be absolute (see resolveModulePath)
+func (ctx *Context) importModule(path string) (*ZnModule, *error.Error) {
+	if module, ok := ctx.modules[path]; ok {
+		return module, nil
+	}
+	for _, importing := range ctx.importStack {
+		if importing == path {
+			return nil, error.ModuleCircularImport(path)
+		}
+	}
+	ctx.importStack = append(ctx.importStack, path)
+	defer func() {
+		ctx.importStack = ctx.importStack[:len(ctx.importStack)-1]
+	}()
+
+	in, err := lex.NewFileStream(path)
+	if err != nil {
+		return nil, err
+	}
+	l := lex.NewLexer(in)
+	p := syntax.NewParser(l)
+	block, err := p.Parse()
+	if err != nil {
+		return nil, err
+	}
+	// a module has its own RootScope
+	scope := NewRootScope()
+	scope.Init(l)
+
+	program := syntax.NewProgramNode(block)
+	if err := evalProgram(ctx, scope, program); err != nil {
+		wrapError(ctx, scope, err)
+		return nil, err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	module := NewZnModule(name, scope)
+	ctx.modules[path] = module
+	return module, nil
+}
+
+// resolveModulePath - get absolute path of the module to import. A relative path
+// is resolved from the directory of current file (or working directory for REPL).
+// If the file extension is omitted, `.zn` will be appended.
+func resolveModulePath(currentFile string, path string) string {
+	if filepath.Ext(path) == "" {
+		path = path + ".zn"
+	}
+	if !filepath.IsAbs(path) {
+		baseDir, _ := os.Getwd()
+		if currentFile != "" && currentFile != "$repl" {
+			baseDir = filepath.Dir(currentFile)
+		}
+		path = filepath.Join(baseDir, path)
+	}
+	if absPath, e := filepath.Abs(path); e == nil {
+		return absPath
+	}
+	return path
+}
+
 // wrapError if lineInfo is missing (mostly for non-syntax errors)
 // If lineInfo missing, then we will add current execution line and hide some part to
 // display errors properly.
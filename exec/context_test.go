@@ -1,10 +1,18 @@
 package exec
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/reg0007/Zn/error"
 	"github.com/reg0007/Zn/lex"
 )
 
@@ -86,8 +94,472 @@ func TestExecuteCode_FAIL(t *testing.T) {
 	}
 }
 
+// error codes (and display texts if given) of each feature
+func TestExecuteCode_Errors(t *testing.T) {
+	const fn = "如何计算税费？\n    已知金额，税率为0.06\n    返回金额×税率\n"
+	cases := []struct {
+		name       string
+		text       string
+		expCode    uint16
+		expDisplay string
+	}{
+		// format
+		{"format huge number", "1E+2000000000之千分位", 0x2609, ""},
+		{"format tiny number", "1E-2000000000之中文数字", 0x2609, ""},
+		{"format huge percentage", "1E+2000000000之百分比", 0x2609, ""},
+		{"uppercase huge amount", "1E+2000000000之大写金额", 0x2609, ""},
+		// dates
+		{"redeclare predefined name", "令日期为「2024」", 0x2502,
+			"在「$repl」中，位于第 1 行发现错误：\n    令日期为「2024」\n    \n‹2502› 标识错误：标识「日期」被重复定义"},
+		{"integer param out of range", "（日期：2024，1，31）之（加天数：1E+30）", 0x2303, ""},
+		// money
+		{"add different currencies", "¥10 ＋ 10美元", 0x2607, ""},
+		{"compare different currencies", "¥10 大于 10美元", 0x2607, ""},
+		{"add decimal to money", "¥10 ＋ 10", 0x2301, ""},
+		{"invalid currency", "（货币：10，「XYZ」）", 0x2707, ""},
+		{"invalid ratio", "令甲为¥10\n甲之（按比例分配：1，-1）", 0x2708,
+			"在「$repl」中，位于第 2 行发现错误：\n    甲之（按比例分配：1，-1）\n    \n‹2708› 参数错误：分配比例「-1」无效：比例不能为负数，且不能全为0"},
+		{"too many parts", "令甲为¥1\n甲之（平分：1000000000）", 0x270E, ""},
+		{"zero parts", "令甲为¥1\n甲之（平分：0）", 0x270E, ""},
+		// match statement
+		{"no matched arm", "对于 5：\n    为 1，2：\n        （显示：1）", 0x2803,
+			"在「$repl」中，位于第 1 行发现错误：\n    对于 5：\n    \n‹2803› 异常：「5」未能匹配「对于」语句的任何分支，且未设置「否则」分支"},
+		{"undefined class", "对于 5：\n    属于 动物：\n        （显示：1）", 0x2501, ""},
+		{"compare string with decimal", "对于「甲」：\n    大于 1：\n        （显示：1）", 0x2304, ""},
+		// variadic params
+		{"too few params", "如何累计？\n    已知首项，余项……\n    返回首项\n（累计）", 0x2701, ""},
+		{"spread non-array", "令甲为1\n（显示：甲……）", 0x2303,
+			"在「$repl」中，位于第 2 行发现错误：\n    （显示：甲……）\n    \n‹2303› 类型错误：输入参数不符合期望之「元组」类型"},
+		// named params
+		{"unknown param name", fn + "（计算税费：100，不存在 == 1）", 0x2709,
+			"在「$repl」中，位于第 4 行发现错误：\n    （计算税费：100，不存在 == 1）\n    \n‹2709› 参数错误：此方法未定义名为「不存在」的参数"},
+		{"duplicated param", fn + "（计算税费：100，金额 == 1）", 0x270A, ""},
+		{"missing param", fn + "（计算税费：税率 == 0.1）", 0x270B, ""},
+		{"too many params", fn + "（计算税费：1，2，3）", 0x2703, ""},
+		{"named param to builtin function", "（显示：甲 == 1）", 0x270C, ""},
+		{"named param to scope setting", "此之（精度：精度 == 2）", 0x270C,
+			"在「$repl」中，位于第 1 行发现错误：\n    此之（精度：精度 == 2）\n    \n‹270C› 参数错误：内置方法不支持按名称输入参数「精度」"},
+		{"named param to loop method", "以项遍历【1，2】：\n    此之（结束：项 == 1）", 0x270C, ""},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext()
+			res := ctx.ExecuteCode(lex.NewTextStream(tt.text), NewRootScope())
+			if !res.HasError || res.Error.GetCode() != tt.expCode {
+				t.Errorf("expect error code %x, got %v", tt.expCode, res)
+				return
+			}
+			if tt.expDisplay != "" && res.Error.Display() != tt.expDisplay {
+				t.Errorf("should return \n%s\n, got \n%s\n", tt.expDisplay, res.Error.Display())
+			}
+		})
+	}
+}
+
+func TestExecuteCode_UncaughtThrow(t *testing.T) {
+	text := `尝试：
+	抛出「库存不足」
+最终：
+	令A为1`
+
+	in := lex.NewTextStream(text)
+	ctx := NewContext()
+	scope := NewRootScope()
+	result := ctx.ExecuteCode(in, scope)
+
+	if result.HasError == false {
+		t.Errorf("should got error, return no error")
+		return
+	}
+	if result.Error.GetErrorClass() != error.ExceptionErrorClass {
+		t.Errorf("should return exception error, got %x", result.Error.GetCode())
+		return
+	}
+	if result.Error.Error() != "库存不足" {
+		t.Errorf("should return text 库存不足, got %s", result.Error.Error())
+	}
+}
+
+func TestExecuteCode_Import(t *testing.T) {
+	dir, e := ioutil.TempDir("", "zn-import")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"工具.zn": `令计数为0
+令税率恒为0.06
+如何求税？
+	已知金额
+	返回（X*Y：金额，税率）
+
+定义二元组：
+	其左值为0
+	其右值为0
+	是为左值，右值
+	何为和？
+		返回（X+Y：其左值，其右值）`,
+		"主程序.zn": `导入「工具」
+导入「./工具.zn」为工具乙
+令甲成为工具之二元组：3，2
+【工具之（求税：100），甲之和，工具乙之税率】`,
+		"私有.zn": `导入「工具」
+工具之计数`,
+		"循环甲.zn": `导入「循环乙」`,
+		"循环乙.zn": `导入「循环甲」`,
+	}
+	for name, text := range files {
+		if e := ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	execFile := func(ctx *Context, name string) Result {
+		in, err := lex.NewFileStream(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ctx.ExecuteCode(in, NewRootScope())
+	}
+
+	t.Run("import module", func(t *testing.T) {
+		ctx := NewContext()
+		res := execFile(ctx, "主程序.zn")
+		if res.HasError {
+			t.Errorf("expect no error, has got error: %s", res.Error.Display())
+			return
+		}
+		if res.Value.String() != "【6.00，5，0.06】" {
+			t.Errorf("expect value: 【6.00，5，0.06】, got: %s", res.Value.String())
+		}
+		// the same file should be loaded only once
+		if len(ctx.modules) != 1 {
+			t.Errorf("expect 1 cached module, got %d", len(ctx.modules))
+		}
+	})
+
+	t.Run("non-constant variables are not exported", func(t *testing.T) {
+		res := execFile(NewContext(), "私有.zn")
+		if !res.HasError || res.Error.GetCode() != 0x2504 {
+			t.Errorf("expect PropertyNotFound error, got %v", res)
+		}
+	})
+
+	t.Run("circular import", func(t *testing.T) {
+		res := execFile(NewContext(), "循环甲.zn")
+		if !res.HasError || res.Error.GetCode() != 0x2506 {
+			t.Errorf("expect ModuleCircularImport error, got %v", res)
+		}
+	})
+}
+
+func TestContext_Register(t *testing.T) {
+	newAccountClass := func() *ClassRef {
+		ref := NewNativeClassRef("账户", func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this := scope.GetTargetThis().(*ZnObject)
+			this.PropList["余额"] = params[0]
+			return nil, nil
+		})
+		ref.AddGetter("余额", func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this := scope.GetTargetThis().(*ZnObject)
+			return this.PropList["余额"], nil
+		})
+		ref.AddMethod("存入", func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this := scope.GetTargetThis().(*ZnObject)
+			amount, ok := params[0].(*ZnDecimal)
+			if !ok {
+				return nil, error.InvalidParamType("decimal")
+			}
+			this.PropList["余额"] = ctx.arith.Add(this.PropList["余额"].(*ZnDecimal), amount)
+			return this, nil
+		})
+		return ref
+	}
+
+	ctx := NewContext()
+	greeting := func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		name, err := FromZnValue(params[0])
+		if err != nil {
+			return nil, err
+		}
+		return ToZnValue(fmt.Sprintf("你好，%s", name))
+	}
+	rate, _ := ToZnValue(json.Number("0.06"))
+
+	if err := ctx.RegisterFunction("问候", greeting); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.RegisterConstant("税率", rate); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.RegisterClass(newAccountClass()); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("call registered values", func(t *testing.T) {
+		text := `令甲成为账户：100
+甲之（存入：税率）
+【（问候：「张三」），甲之余额】`
+		res := ctx.ExecuteCode(lex.NewTextStream(text), NewRootScope())
+		if res.HasError {
+			t.Errorf("expect no error, has got error: %s", res.Error.Display())
+			return
+		}
+		if res.Value.String() != "【「你好，张三」，100.06】" {
+			t.Errorf("expect value: 【「你好，张三」，100.06】, got: %s", res.Value.String())
+		}
+	})
+
+	t.Run("redeclare registered values", func(t *testing.T) {
+		if err := ctx.RegisterFunction("显示", greeting); err == nil || err.GetCode() != 0x2502 {
+			t.Errorf("expect NameRedeclared error, got %v", err)
+		}
+		if err := ctx.RegisterClass(newAccountClass()); err == nil || err.GetCode() != 0x2502 {
+			t.Errorf("expect NameRedeclared error, got %v", err)
+		}
+		res := ctx.ExecuteCode(lex.NewTextStream("定义账户：\n\t其余额为0"), NewRootScope())
+		if !res.HasError || res.Error.GetCode() != 0x2502 {
+			t.Errorf("expect NameRedeclared error, got %v", res)
+		}
+	})
+
+	t.Run("registered values are not shared with other contexts", func(t *testing.T) {
+		res := NewContext().ExecuteCode(lex.NewTextStream("（问候：「张三」）"), NewRootScope())
+		if !res.HasError || res.Error.GetCode() != 0x2501 {
+			t.Errorf("expect NameNotDefined error, got %v", res)
+		}
+	})
+}
+
+func TestExecuteCode_Limits(t *testing.T) {
+	endlessLoop := `令A为0
+每当真：
+	A为A + 1`
+	caughtLoop := `尝试：
+	每当真：
+		令B为1
+捕获：
+	令C为2
+最终：
+	返回3`
+	recursion := `如何递归？
+	（递归）
+（递归）`
+
+	cases := []struct {
+		name    string
+		text    string
+		limits  Limits
+		cancel  bool
+		expCode uint16
+	}{
+		{"max statements", endlessLoop, Limits{MaxStatements: 1000}, false, 0x2903},
+		{"could not be caught", caughtLoop, Limits{MaxStatements: 1000}, false, 0x2903},
+		{"max call depth", recursion, Limits{MaxCallDepth: 50}, false, 0x2904},
+		{"max duration", endlessLoop, Limits{MaxDuration: 20 * time.Millisecond}, false, 0x2902},
+		{"cancelled", endlessLoop, Limits{}, true, 0x2901},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext()
+			ctx.SetLimits(tt.limits)
+
+			goCtx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				go func() {
+					time.Sleep(20 * time.Millisecond)
+					cancel()
+				}()
+			}
+			res := ctx.ExecuteCodeWithContext(goCtx, lex.NewTextStream(tt.text), NewRootScope())
+			if !res.HasError || res.Error.GetCode() != tt.expCode {
+				t.Errorf("expect error code %x, got %v", tt.expCode, res)
+				return
+			}
+
+			// limits are reset on next execution
+			res = ctx.ExecuteCode(lex.NewTextStream("（X+Y：1，2）"), NewRootScope())
+			if res.HasError {
+				t.Errorf("expect no error, has got error: %s", res.Error.Display())
+			}
+		})
+	}
+}
+
+func TestExecuteCode_CallStack(t *testing.T) {
+	text := `如何相加？
+	已知甲
+	返回（X+Y：甲，「a」）
+
+定义计算器：
+	何为结果？
+		返回（相加：1）
+
+令乙成为计算器
+令丙为1
+乙之结果`
+
+	res := NewContext().ExecuteCode(lex.NewTextStream(text), NewRootScope())
+	if !res.HasError {
+		t.Errorf("should got error, return no error")
+		return
+	}
+	expStack := []error.StackFrame{
+		{Name: "相加", File: "$repl", LineNum: 3},
+		{Name: "结果", File: "$repl", LineNum: 7},
+		{Name: "", File: "$repl", LineNum: 11},
+	}
+	if !reflect.DeepEqual(res.Error.GetStack(), expStack) {
+		t.Errorf("expect stack %v, got %v", expStack, res.Error.GetStack())
+	}
+	if res.Error.GetCursor().LineNum != 3 {
+		t.Errorf("expect error on line 3, got line %d", res.Error.GetCursor().LineNum)
+	}
+
+	t.Run("no stack outside functions", func(t *testing.T) {
+		res := NewContext().ExecuteCode(lex.NewTextStream("（X+Y：「a」）"), NewRootScope())
+		if !res.HasError || res.Error.GetStack() != nil {
+			t.Errorf("expect error without stack, got %v", res)
+		}
+	})
+
+	t.Run("get call stack from native function", func(t *testing.T) {
+		var stack []error.StackFrame
+		ctx := NewContext()
+		ctx.RegisterFunction("记录", func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			stack = ctx.GetCallStack()
+			return NewZnNull(), nil
+		})
+		text := "如何测试？\n\t（记录）\n\n（测试）"
+		if res := ctx.ExecuteCode(lex.NewTextStream(text), NewRootScope()); res.HasError {
+			t.Errorf("expect no error, has got error: %s", res.Error.Display())
+			return
+		}
+		expStack := []error.StackFrame{
+			{Name: "测试", File: "$repl", LineNum: 2},
+			{Name: "", File: "$repl", LineNum: 4},
+		}
+		if !reflect.DeepEqual(stack, expStack) {
+			t.Errorf("expect stack %v, got %v", expStack, stack)
+		}
+	})
+}
+
+// run with `go test -race` to detect data races between contexts
+func TestContext_Concurrency(t *testing.T) {
+	text := `定义计数器：
+	其值为0
+	如何递增？
+		其值为（X+Y：其值，1）
+
+令甲成为计数器
+令列表为【】
+令累计为0
+令标记为真
+以数遍历【1，2，3，4，5，6，7，8，9，10】：
+	甲之（递增）
+	累计为 累计 + 数 × 参数
+	如果标记等于真：
+		标记为假
+【甲之值，累计，标记，（倍数：参数）】`
+
+	const routines = 8
+	var wg sync.WaitGroup
+	results := make([]Result, routines)
+
+	for i := 0; i < routines; i++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			ctx := NewContext()
+			ctx.RegisterConstant("参数", NewZnDecimalFromInt(idx, 0))
+			ctx.RegisterFunction("倍数", func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				return ctx.arith.Mul(params[0].(*ZnDecimal), NewZnDecimalFromInt(2, 0)), nil
+			})
+			results[idx] = ctx.ExecuteCode(lex.NewTextStream(text), NewRootScope())
+		}(i)
+	}
+	wg.Wait()
+
+	for idx, res := range results {
+		if res.HasError {
+			t.Errorf("[#%d] expect no error, has got error: %s", idx, res.Error.Display())
+			continue
+		}
+		expect := fmt.Sprintf("【10，%d，假，%d】", 55*idx, 2*idx)
+		if res.Value.String() != expect {
+			t.Errorf("[#%d] expect value: %s, got: %s", idx, expect, res.Value.String())
+		}
+	}
+}
+
+func TestContext_IsolatedGlobals(t *testing.T) {
+	ctxA := NewContext()
+	ctxB := NewContext()
+	if ctxA.globals["真"] == ctxB.globals["真"] {
+		t.Errorf("predefined values should not be shared between contexts")
+	}
+
+	trueVal := ctxA.globals["真"].(*ZnBool)
+	if trueVal.Rev().Value != false || trueVal.Value != true {
+		t.Errorf("Rev() should not change the original value")
+	}
+}
+
 // create decimal (and ignore errors)
 func newDecimal(value string) *ZnDecimal {
 	dat, _ := NewZnDecimal(value)
 	return dat
 }
+
+func TestContext_ArithSettings(t *testing.T) {
+	ctx := NewContext()
+	if err := ctx.SetPrecision(0); err == nil || err.GetCode() != 0x2606 {
+		t.Errorf("expect InvalidPrecision error, got %v", err)
+	}
+	if err := ctx.SetRoundingMode(RoundingMode(100)); err == nil || err.GetCode() != 0x2605 {
+		t.Errorf("expect InvalidRoundingMode error, got %v", err)
+	}
+	ctx.SetPrecision(3)
+	ctx.SetRoundingMode(RoundFloor)
+
+	text := `
+如何计算？
+	已知值
+	此之（精度：5）
+	返回值 ÷ 3
+
+【2 ÷ 3，（计算：2），2 ÷ 3，（四舍五入：2.5，0），此之精度】`
+	res := ctx.ExecuteCode(lex.NewTextStream(text), NewRootScope())
+	if res.HasError {
+		t.Errorf("expect no error, has got error: %s", res.Error.Display())
+		return
+	}
+	expect := "【0.666，0.66666，0.666，2，3】"
+	if res.Value.String() != expect {
+		t.Errorf("expect value: %s, got: %s", expect, res.Value.String())
+	}
+}
+
+func TestContext_ClockAndLocation(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetClock(func() time.Time {
+		return time.Date(2024, 3, 5, 23, 30, 0, 0, time.UTC)
+	})
+	ctx.SetLocation(time.FixedZone("UTC+8", 8*3600))
+
+	text := `【（现在），（今天），（时刻：「2024-03-06 08:00」）之（相差：（现在）），（日期：「2000年3月6日」）之周岁】`
+	res := ctx.ExecuteCode(lex.NewTextStream(text), NewRootScope())
+	if res.HasError {
+		t.Errorf("expect no error, has got error: %s", res.Error.Display())
+		return
+	}
+	expect := "【2024年3月6日 07:30:00，2024年3月6日，30分钟，24】"
+	if res.Value.String() != expect {
+		t.Errorf("expect value: %s, got: %s", expect, res.Value.String())
+	}
+}
@@ -0,0 +1,138 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/reg0007/Zn/error"
+)
+
+// ToZnValue - convert a Go value to ZnValue, so that host program could pass data to Zn code.
+//
+// Supported types:
+//   nil                    -> 空
+//   string                 -> 文本
+//   bool                   -> 二象
+//   int, int64, float64    -> 数值
+//   json.Number            -> 数值 (a decimal string, e.g. "12.50")
+//   []interface{}          -> 元组
+//   map[string]interface{} -> 列表 (keys are sorted)
+//   time.Time              -> 时刻
+//   time.Duration          -> 时长
+//   *big.Rat               -> 分数
+//   ZnValue                -> (the value itself)
+func ToZnValue(value interface{}) (ZnValue, *error.Error) {
+	switch v := value.(type) {
+	case nil:
+		return NewZnNull(), nil
+	case ZnValue:
+		return v, nil
+	case string:
+		return NewZnString(v), nil
+	case bool:
+		return NewZnBool(v), nil
+	case int:
+		return NewZnDecimalFromInt(v, 0), nil
+	case int64:
+		return NewZnDecimal(strconv.FormatInt(v, 10))
+	case float64:
+		return NewZnDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+	case json.Number:
+		return NewZnDecimal(string(v))
+	case time.Time:
+		return NewZnTime(v), nil
+	case time.Duration:
+		return NewZnDuration(v), nil
+	case *big.Rat:
+		return NewZnFraction(new(big.Rat).Set(v)), nil
+	case []interface{}:
+		items := []ZnValue{}
+		for _, item := range v {
+			znItem, err := ToZnValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, znItem)
+		}
+		return NewZnArray(items), nil
+	case map[string]interface{}:
+		keys := []string{}
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		kvPairs := []KVPair{}
+		for _, k := range keys {
+			znItem, err := ToZnValue(v[k])
+			if err != nil {
+				return nil, err
+			}
+			kvPairs = append(kvPairs, KVPair{
+				Key:   k,
+				Value: znItem,
+			})
+		}
+		return NewZnHashMap(kvPairs), nil
+	}
+	return nil, error.InvalidHostValueType(fmt.Sprintf("%T", value))
+}
+
+// FromZnValue - convert a ZnValue to Go value, so that host program could read the result of Zn code.
+//
+// Supported types:
+//   空   -> nil
+//   文本 -> string
+//   二象 -> bool
+//   数值 -> json.Number (a decimal string without precision loss, e.g. "12.50")
+//   元组 -> []interface{}
+//   列表 -> map[string]interface{}
+//   日期 -> time.Time (00:00:00 UTC of the day)
+//   时刻 -> time.Time
+//   时长 -> time.Duration
+//   分数 -> *big.Rat
+func FromZnValue(value ZnValue) (interface{}, *error.Error) {
+	switch v := value.(type) {
+	case *ZnNull:
+		return nil, nil
+	case *ZnString:
+		return v.Value, nil
+	case *ZnBool:
+		return v.Value, nil
+	case *ZnDecimal:
+		return json.Number(v.plainString()), nil
+	case *ZnDate:
+		return v.Value, nil
+	case *ZnTime:
+		return v.Value, nil
+	case *ZnDuration:
+		return v.Value, nil
+	case *ZnFraction:
+		return new(big.Rat).Set(v.Value), nil
+	case *ZnArray:
+		items := []interface{}{}
+		for _, item := range v.Value {
+			goItem, err := FromZnValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, goItem)
+		}
+		return items, nil
+	case *ZnHashMap:
+		hm := map[string]interface{}{}
+		for _, k := range v.KeyOrder {
+			goItem, err := FromZnValue(v.Value[k])
+			if err != nil {
+				return nil, err
+			}
+			hm[k] = goItem
+		}
+		return hm, nil
+	}
+	return nil, error.InvalidHostValueType(fmt.Sprintf("%T", value))
+}
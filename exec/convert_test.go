@@ -0,0 +1,90 @@
+package exec
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestToZnValue(t *testing.T) {
+	cases := []struct {
+		name  string
+		input interface{}
+		str   string
+	}{
+		{"nil", nil, "空"},
+		{"string", "你好", "「你好」"},
+		{"bool", true, "真"},
+		{"int", 42, "42"},
+		{"int64", int64(-9000000000), "-9000000000"},
+		{"float64", 0.25, "0.25"},
+		{"decimal string", json.Number("12.50"), "12.50"},
+		{"array", []interface{}{1, "a", false}, "【1，「a」，假】"},
+		{"hashmap", map[string]interface{}{"乙": 2, "甲": []interface{}{}}, "【乙 == 2，甲 == 【】】"},
+		{"time", time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC), "2024年3月5日 14:30:00"},
+		{"duration", 90 * time.Minute, "1小时30分钟"},
+		{"fraction", big.NewRat(2, 6), "1/3"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ToZnValue(tt.input)
+			if err != nil {
+				t.Errorf("expect no error, got %s", err.Error())
+				return
+			}
+			if v.String() != tt.str {
+				t.Errorf("expect value %s, got %s", tt.str, v.String())
+			}
+		})
+	}
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := ToZnValue(struct{}{})
+		if err == nil || err.GetCode() != 0x2306 {
+			t.Errorf("expect InvalidHostValueType error, got %v", err)
+		}
+	})
+}
+
+func TestFromZnValue(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  ZnValue
+		expect interface{}
+	}{
+		{"null", NewZnNull(), nil},
+		{"string", NewZnString("你好"), "你好"},
+		{"bool", NewZnBool(false), false},
+		{"decimal", newDecimal("12.50"), json.Number("12.50")},
+		{"small decimal", newDecimal("-0.000000012"), json.Number("-0.000000012")},
+		{"large decimal", newDecimal("1.2e20"), json.Number("120000000000000000000")},
+		{"array", NewZnArray([]ZnValue{NewZnDecimalFromInt(1, 0), NewZnString("a")}), []interface{}{json.Number("1"), "a"}},
+		{"hashmap", NewZnHashMap([]KVPair{{"甲", NewZnBool(true)}}), map[string]interface{}{"甲": true}},
+		{"date", NewZnDate(2024, 3, 5), time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"duration", NewZnDuration(time.Hour), time.Hour},
+		{"fraction", NewZnFraction(big.NewRat(1, 3)), big.NewRat(1, 3)},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := FromZnValue(tt.input)
+			if err != nil {
+				t.Errorf("expect no error, got %s", err.Error())
+				return
+			}
+			if !reflect.DeepEqual(v, tt.expect) {
+				t.Errorf("expect value %#v, got %#v", tt.expect, v)
+			}
+		})
+	}
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := FromZnValue(NewZnNativeFunction("f", nil))
+		if err == nil || err.GetCode() != 0x2306 {
+			t.Errorf("expect InvalidHostValueType error, got %v", err)
+		}
+	})
+}
@@ -2,13 +2,16 @@ package exec
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/reg0007/Zn/error"
 )
 
-var predefinedValues map[string]ZnValue
-
 // （显示） 方法的执行逻辑
 var displayExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
 	// display format string
@@ -44,7 +47,7 @@ var addValueExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (Z
 		return decimals[0], nil
 	}
 
-	sum := ctx.arith.Add(decimals[0], decimals[1:]...)
+	sum := getArith(ctx, scope).Add(decimals[0], decimals[1:]...)
 	return sum, nil
 }
 
@@ -67,7 +70,7 @@ var subValueExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (Z
 		return decimals[0], nil
 	}
 
-	sum := ctx.arith.Sub(decimals[0], decimals[1:]...)
+	sum := getArith(ctx, scope).Sub(decimals[0], decimals[1:]...)
 	return sum, nil
 }
 
@@ -89,7 +92,7 @@ var mulValueExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (Z
 		return decimals[0], nil
 	}
 
-	sum := ctx.arith.Mul(decimals[0], decimals[1:]...)
+	sum := getArith(ctx, scope).Mul(decimals[0], decimals[1:]...)
 	return sum, nil
 }
 
@@ -110,7 +113,7 @@ var divValueExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (Z
 		return decimals[0], nil
 	}
 
-	return ctx.arith.Div(decimals[0], decimals[1:]...)
+	return getArith(ctx, scope).Div(decimals[0], decimals[1:]...)
 }
 
 var probeExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
@@ -147,6 +150,300 @@ var defaultDecimalClassRef = &ClassRef{
 	},
 }
 
+// decimalMathFunc - a math function whose first param is a decimal, e.g. （四舍五入：数，2）.
+// It's exported both as a predefined function and a method of decimals (e.g. 数之（四舍五入：2）).
+type decimalMathFunc struct {
+	// min & max number of args, except the decimal itself; maxArgs = -1 means unlimited
+	minArgs int
+	maxArgs int
+	fn      func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error)
+}
+
+// asFunction - export as predefined function, the decimal itself is the first param
+func (mf decimalMathFunc) asFunction(name string) *ZnFunction {
+	return NewZnNativeFunction(name, func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		if len(params) == 0 {
+			return nil, error.LeastParamsError(mf.minArgs + 1)
+		}
+		this, ok := params[0].(*ZnDecimal)
+		if !ok {
+			return nil, error.InvalidParamType("decimal")
+		}
+		if err := mf.checkArgs(params[1:], 1); err != nil {
+			return nil, err
+		}
+		return mf.fn(getArith(ctx, scope), this, params[1:])
+	})
+}
+
+// asMethod - export as a method of decimals
+func (mf decimalMathFunc) asMethod(name string) *ClosureRef {
+	return NewNativeClosureRef(name, func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		this, ok := scope.GetTargetThis().(*ZnDecimal)
+		if !ok {
+			return nil, error.NewErrorSLOT("invalid object type")
+		}
+		if err := mf.checkArgs(params, 0); err != nil {
+			return nil, err
+		}
+		return mf.fn(getArith(ctx, scope), this, params)
+	})
+}
+
+func (mf decimalMathFunc) checkArgs(args []ZnValue, offset int) *error.Error {
+	if mf.minArgs == mf.maxArgs && len(args) != mf.minArgs {
+		return error.ExactParamsError(mf.minArgs + offset)
+	}
+	if len(args) < mf.minArgs {
+		return error.LeastParamsError(mf.minArgs + offset)
+	}
+	if mf.maxArgs >= 0 && len(args) > mf.maxArgs {
+		return error.MostParamsError(mf.maxArgs + offset)
+	}
+	return nil
+}
+
+// getDecimalParams - assert all params are decimals
+func getDecimalParams(params []ZnValue) ([]*ZnDecimal, *error.Error) {
+	decimals := []*ZnDecimal{}
+	for _, param := range params {
+		v, ok := param.(*ZnDecimal)
+		if !ok {
+			return nil, error.InvalidParamType("decimal")
+		}
+		decimals = append(decimals, v)
+	}
+	return decimals, nil
+}
+
+// getIntegerParam - assert the param is an integer
+func getIntegerParam(param ZnValue) (int, *error.Error) {
+	v, ok := param.(*ZnDecimal)
+	if !ok {
+		return 0, error.InvalidParamType("integer")
+	}
+	n, err := v.asInteger()
+	if err != nil {
+		return 0, error.InvalidParamType("integer")
+	}
+	return n, nil
+}
+
+// getRoundingModeParam - get rounding mode from its name (e.g. 「四舍六入五成双」)
+func getRoundingModeParam(param ZnValue) (RoundingMode, *error.Error) {
+	v, ok := param.(*ZnString)
+	if !ok {
+		return 0, error.InvalidParamType("string")
+	}
+	mode, ok := RoundingModeNames[v.Value]
+	if !ok {
+		return 0, error.InvalidRoundingMode(v.Value)
+	}
+	return mode, nil
+}
+
+var decimalMathFuncs = map[string]decimalMathFunc{
+	// 四舍五入：位数，舍入模式 - the rounding mode is decided by current scope (「四舍五入」 by default)
+	"四舍五入": {1, 2, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		places, err := getIntegerParam(args[0])
+		if err != nil {
+			return nil, err
+		}
+		mode := arith.rounding
+		if len(args) == 2 {
+			if mode, err = getRoundingModeParam(args[1]); err != nil {
+				return nil, err
+			}
+		}
+		return arith.Round(this, places, mode)
+	}},
+	// 取整：舍入模式 - the rounding mode is 「向下」 by default
+	"取整": {0, 1, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		mode := RoundFloor
+		if len(args) == 1 {
+			var err *error.Error
+			if mode, err = getRoundingModeParam(args[0]); err != nil {
+				return nil, err
+			}
+		}
+		return arith.Round(this, 0, mode)
+	}},
+	"绝对值": {0, 0, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		return arith.Abs(this), nil
+	}},
+	"取余": {1, 1, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		decimals, err := getDecimalParams(args)
+		if err != nil {
+			return nil, err
+		}
+		return arith.Mod(this, decimals[0])
+	}},
+	"整除": {1, 1, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		decimals, err := getDecimalParams(args)
+		if err != nil {
+			return nil, err
+		}
+		return arith.IntDiv(this, decimals[0])
+	}},
+	// 乘方：指数 - the exponent must be an integer
+	"乘方": {1, 1, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		n, err := getIntegerParam(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return arith.Pow(this, n)
+	}},
+	"开方": {0, 0, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		return arith.Sqrt(this)
+	}},
+	"最大值": {0, -1, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		decimals, err := getDecimalParams(args)
+		if err != nil {
+			return nil, err
+		}
+		return arith.Max(this, decimals...), nil
+	}},
+	"最小值": {0, -1, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		decimals, err := getDecimalParams(args)
+		if err != nil {
+			return nil, err
+		}
+		return arith.Min(this, decimals...), nil
+	}},
+	// 精确除：除数 - exact division that returns a fraction, e.g. （精确除：1，3） -> 1/3
+	"精确除": {1, 1, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		decimals, err := getDecimalParams(args)
+		if err != nil {
+			return nil, err
+		}
+		r, err := quoDecimals(this, decimals[0])
+		if err != nil {
+			return nil, err
+		}
+		return NewZnFraction(r), nil
+	}},
+	// 比较：另一数值 - returns -1 if less than, 0 if equals and 1 if greater than the other one
+	"比较": {1, 1, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		decimals, err := getDecimalParams(args)
+		if err != nil {
+			return nil, err
+		}
+		return NewZnDecimalFromInt(arith.Cmp(this, decimals[0]), 0), nil
+	}},
+}
+
+// getFormatRounding - get (places, rounding mode) from optional args of format functions.
+// If places is not given, returns places = nil, i.e. no rounding at all.
+func getFormatRounding(arith *Arith, args []ZnValue) (*int, RoundingMode, *error.Error) {
+	mode := arith.rounding
+	if len(args) == 0 {
+		return nil, mode, nil
+	}
+	places, err := getIntegerParam(args[0])
+	if err != nil {
+		return nil, mode, err
+	}
+	if len(args) == 2 {
+		if mode, err = getRoundingModeParam(args[1]); err != nil {
+			return nil, mode, err
+		}
+	}
+	return &places, mode, nil
+}
+
+// decimalFormatFuncs - format decimals to strings. They're exported both as getters
+// (e.g. 数之大写金额) and methods (e.g. 数之（千分位：2）) of decimals.
+var decimalFormatFuncs = map[string]decimalMathFunc{
+	// 大写金额：舍入模式 - rounded to 分, e.g. 壹万贰仟叁佰肆拾伍元陆角柒分
+	"大写金额": {0, 1, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		mode := arith.rounding
+		if len(args) == 1 {
+			var err *error.Error
+			if mode, err = getRoundingModeParam(args[0]); err != nil {
+				return nil, err
+			}
+		}
+		txt, err := formatChineseAmount(arith, this, mode)
+		if err != nil {
+			return nil, err
+		}
+		return NewZnString(txt), nil
+	}},
+	// 中文数字 - e.g. 一千二百三十四点五
+	"中文数字": {0, 0, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		if err := checkFormatDigits(this); err != nil {
+			return nil, err
+		}
+		return NewZnString(formatChineseNumeral(this)), nil
+	}},
+	// 千分位：位数，舍入模式 - e.g. 1,234,567.89
+	"千分位": {0, 2, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		places, mode, err := getFormatRounding(arith, args)
+		if err != nil {
+			return nil, err
+		}
+		if places != nil {
+			if this, err = arith.Round(this, *places, mode); err != nil {
+				return nil, err
+			}
+		}
+		if err = checkFormatDigits(this); err != nil {
+			return nil, err
+		}
+		return NewZnString(formatThousands(this)), nil
+	}},
+	// 保留小数：位数，舍入模式 - always display the given decimal places, e.g. 12.30
+	"保留小数": {1, 2, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		places, mode, err := getFormatRounding(arith, args)
+		if err != nil {
+			return nil, err
+		}
+		rounded, err := arith.Round(this, *places, mode)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkFormatDigits(rounded); err != nil {
+			return nil, err
+		}
+		return NewZnString(rounded.plainString()), nil
+	}},
+	// 百分比：位数，舍入模式 - e.g. 15.5%
+	"百分比": {0, 2, func(arith *Arith, this *ZnDecimal, args []ZnValue) (ZnValue, *error.Error) {
+		places, mode, err := getFormatRounding(arith, args)
+		if err != nil {
+			return nil, err
+		}
+		if places != nil {
+			// round the percentage number, i.e. this * 100
+			if this, err = arith.Round(this, *places+2, mode); err != nil {
+				return nil, err
+			}
+		}
+		if err = checkFormatDigits(this); err != nil {
+			return nil, err
+		}
+		return NewZnString(formatPercent(this)), nil
+	}},
+}
+
+// math & format functions are exported as methods of decimals on init() to avoid initialization cycle.
+func init() {
+	defaultDecimalClassRef.MethodList = map[string]*ClosureRef{}
+	for name, mf := range decimalMathFuncs {
+		defaultDecimalClassRef.MethodList[name] = mf.asMethod(name)
+	}
+	// 数之绝对值
+	defaultDecimalClassRef.GetterList["绝对值"] = decimalMathFuncs["绝对值"].asMethod("绝对值")
+
+	for name, mf := range decimalFormatFuncs {
+		defaultDecimalClassRef.MethodList[name] = mf.asMethod(name)
+		// 保留小数 requires places, thus it's not a getter
+		if mf.minArgs == 0 {
+			defaultDecimalClassRef.GetterList[name] = mf.asMethod(name)
+		}
+	}
+}
+
 var defaultArrayClassRef = &ClassRef{
 	Name: "数组",
 	Constructor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
@@ -235,12 +532,1628 @@ var defaultArrayClassRef = &ClassRef{
 	},
 }
 
-// init function
+// methods of arrays are assigned on init() to avoid initialization cycle, since
+// some of them create new arrays.
+func init() {
+	defaultArrayClassRef.MethodList = map[string]*ClosureRef{
+		// 【1，2】之（添加：3，4） -> 【1，2，3，4】
+		"添加": {
+			Name: "添加",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) == 0 {
+					return nil, error.LeastParamsError(1)
+				}
+				this.Value = append(this.Value, params...)
+				return this, nil
+			},
+		},
+		// remove the last item and return it
+		"弹出": {
+			Name: "弹出",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(this.Value) == 0 {
+					return NewZnNull(), nil
+				}
+				last := this.Value[len(this.Value)-1]
+				this.Value = this.Value[:len(this.Value)-1]
+				return last, nil
+			},
+		},
+		// 【1，3】之（插入：1，2） -> 【1，2，3】
+		"插入": {
+			Name: "插入",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 2 {
+					return nil, error.ExactParamsError(2)
+				}
+				idx, err := getIndexParam(params[0], len(this.Value))
+				if err != nil {
+					return nil, err
+				}
+				newValue := append([]ZnValue{}, this.Value[:idx]...)
+				newValue = append(newValue, params[1])
+				this.Value = append(newValue, this.Value[idx:]...)
+				return this, nil
+			},
+		},
+		// remove the item at given index and return it
+		"删除": {
+			Name: "删除",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 1 {
+					return nil, error.ExactParamsError(1)
+				}
+				idx, err := getIndexParam(params[0], len(this.Value)-1)
+				if err != nil {
+					return nil, err
+				}
+				item := this.Value[idx]
+				this.Value = append(this.Value[:idx], this.Value[idx+1:]...)
+				return item, nil
+			},
+		},
+		// 【1，2，3，4】之（切片：1，3） -> 【2，3】; the end index could be omitted.
+		"切片": {
+			Name: "切片",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) == 0 {
+					return nil, error.LeastParamsError(1)
+				}
+				if len(params) > 2 {
+					return nil, error.MostParamsError(2)
+				}
+				start, err := getIndexParam(params[0], len(this.Value))
+				if err != nil {
+					return nil, err
+				}
+				end := len(this.Value)
+				if len(params) == 2 {
+					if end, err = getIndexParam(params[1], len(this.Value)); err != nil {
+						return nil, err
+					}
+				}
+				if start > end {
+					return nil, error.IndexOutOfRange()
+				}
+				return NewZnArray(append([]ZnValue{}, this.Value[start:end]...)), nil
+			},
+		},
+		// get the index of first matched item, return -1 if not found
+		"查找": {
+			Name: "查找",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 1 {
+					return nil, error.ExactParamsError(1)
+				}
+				for idx, item := range this.Value {
+					eq, err := compareValues(item, params[0], CmpEq)
+					if err != nil {
+						return nil, err
+					}
+					if eq {
+						return NewZnDecimalFromInt(idx, 0), nil
+					}
+				}
+				return NewZnDecimalFromInt(-1, 0), nil
+			},
+		},
+		"包含": {
+			Name: "包含",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 1 {
+					return nil, error.ExactParamsError(1)
+				}
+				for _, item := range this.Value {
+					eq, err := compareValues(item, params[0], CmpEq)
+					if err != nil {
+						return nil, err
+					}
+					if eq {
+						return NewZnBool(true), nil
+					}
+				}
+				return NewZnBool(false), nil
+			},
+		},
+		// reverse items (in place)
+		"反转": {
+			Name: "反转",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				for i, j := 0, len(this.Value)-1; i < j; i, j = i+1, j-1 {
+					this.Value[i], this.Value[j] = this.Value[j], this.Value[i]
+				}
+				return this, nil
+			},
+		},
+		// sort items (in place). By default, decimals or strings are sorted in ascending order;
+		// or a comparator function (已知甲，乙) could be given, which returns 真 if 甲 should be
+		// placed before 乙 (or returns a negative decimal, like most languages do).
+		"排序": {
+			Name: "排序",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) > 1 {
+					return nil, error.MostParamsError(1)
+				}
+				var lessFn = compareSortItems
+				if len(params) == 1 {
+					fn, ok := params[0].(*ZnFunction)
+					if !ok {
+						return nil, error.InvalidParamType("function")
+					}
+					lessFn = func(a ZnValue, b ZnValue) (bool, *error.Error) {
+						result, err := callFunction(ctx, scope, fn, []ZnValue{a, b})
+						if err != nil {
+							return false, err
+						}
+						switch v := result.(type) {
+						case *ZnBool:
+							return v.Value, nil
+						case *ZnDecimal:
+							return v.co.Sign() < 0, nil
+						}
+						return false, error.InvalidExprType("bool", "decimal")
+					}
+				}
+				var sortErr *error.Error
+				sort.SliceStable(this.Value, func(i, j int) bool {
+					if sortErr != nil {
+						return false
+					}
+					less, err := lessFn(this.Value[i], this.Value[j])
+					if err != nil {
+						sortErr = err
+					}
+					return less
+				})
+				if sortErr != nil {
+					return nil, sortErr
+				}
+				return this, nil
+			},
+		},
+		// 【1，2，3】之（映射：加倍） -> 【2，4，6】
+		"映射": {
+			Name: "映射",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 1 {
+					return nil, error.ExactParamsError(1)
+				}
+				fn, ok := params[0].(*ZnFunction)
+				if !ok {
+					return nil, error.InvalidParamType("function")
+				}
+				items := []ZnValue{}
+				for _, item := range this.Value {
+					result, err := callFunction(ctx, scope, fn, []ZnValue{item})
+					if err != nil {
+						return nil, err
+					}
+					items = append(items, result)
+				}
+				return NewZnArray(items), nil
+			},
+		},
+		// keep items that the function returns 真
+		"过滤": {
+			Name: "过滤",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 1 {
+					return nil, error.ExactParamsError(1)
+				}
+				fn, ok := params[0].(*ZnFunction)
+				if !ok {
+					return nil, error.InvalidParamType("function")
+				}
+				items := []ZnValue{}
+				for _, item := range this.Value {
+					result, err := callFunction(ctx, scope, fn, []ZnValue{item})
+					if err != nil {
+						return nil, err
+					}
+					keep, ok := result.(*ZnBool)
+					if !ok {
+						return nil, error.InvalidExprType("bool")
+					}
+					if keep.Value {
+						items = append(items, item)
+					}
+				}
+				return NewZnArray(items), nil
+			},
+		},
+		// 【1，2，3】之（归约：求和，0） -> 6; the function accepts two params: (累计值，当前项)
+		"归约": {
+			Name: "归约",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 2 {
+					return nil, error.ExactParamsError(2)
+				}
+				fn, ok := params[0].(*ZnFunction)
+				if !ok {
+					return nil, error.InvalidParamType("function")
+				}
+				acc := params[1]
+				for _, item := range this.Value {
+					result, err := callFunction(ctx, scope, fn, []ZnValue{acc, item})
+					if err != nil {
+						return nil, err
+					}
+					acc = result
+				}
+				return acc, nil
+			},
+		},
+		// 【「甲」，「乙」】之（连接：「、」） -> 「甲、乙」
+		"连接": {
+			Name: "连接",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnArray)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) > 1 {
+					return nil, error.MostParamsError(1)
+				}
+				sep := ""
+				if len(params) == 1 {
+					vsep, ok := params[0].(*ZnString)
+					if !ok {
+						return nil, error.InvalidParamType("string")
+					}
+					sep = vsep.Value
+				}
+				items := []string{}
+				for _, item := range this.Value {
+					items = append(items, textOf(item))
+				}
+				return NewZnString(strings.Join(items, sep)), nil
+			},
+		},
+	}
+}
+
+// getIndexParam - get index (integer) from param, which should be in range [0, max]
+func getIndexParam(param ZnValue, max int) (int, *error.Error) {
+	v, ok := param.(*ZnDecimal)
+	if !ok {
+		return 0, error.InvalidParamType("integer")
+	}
+	idx, err := v.asInteger()
+	if err != nil {
+		return 0, error.InvalidParamType("integer")
+	}
+	if idx < 0 || idx > max {
+		return 0, error.IndexOutOfRange()
+	}
+	return idx, nil
+}
+
+// compareSortItems - default comparator for sorting: decimals or strings in ascending order
+func compareSortItems(a ZnValue, b ZnValue) (bool, *error.Error) {
+	switch va := a.(type) {
+	case *ZnDecimal:
+		if vb, ok := b.(*ZnDecimal); ok {
+			return compareValues(va, vb, CmpLt)
+		}
+	case *ZnString:
+		if vb, ok := b.(*ZnString); ok {
+			return va.Value < vb.Value, nil
+		}
+	}
+	return false, error.InvalidExprType("decimal", "string")
+}
+
+var defaultStringClassRef = &ClassRef{
+	Name: "文本",
+	Constructor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		return NewZnNull(), nil
+	},
+}
+
+// getters & methods of strings are assigned on init() to avoid initialization cycle.
+// NOTICE: all lengths & indexes are counted by characters (runes) instead of bytes.
 func init() {
-	//// predefined values - those variables (symbols) are defined before
-	//// any execution procedure.
-	//// NOTICE: those variables are all constants!
-	predefinedValues = map[string]ZnValue{
+	defaultStringClassRef.GetterList = map[string]*ClosureRef{
+		"长度": {
+			Name: "长度",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				return NewZnDecimalFromInt(utf8.RuneCountInString(this.Value), 0), nil
+			},
+		},
+		// returns 「」 if the string is empty
+		"首字": {
+			Name: "首字",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				chars := []rune(this.Value)
+				if len(chars) == 0 {
+					return NewZnString(""), nil
+				}
+				return NewZnString(string(chars[0])), nil
+			},
+		},
+		"尾字": {
+			Name: "尾字",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				chars := []rune(this.Value)
+				if len(chars) == 0 {
+					return NewZnString(""), nil
+				}
+				return NewZnString(string(chars[len(chars)-1])), nil
+			},
+		},
+	}
+	defaultStringClassRef.MethodList = map[string]*ClosureRef{
+		// 「总计：」之（拼接：100，「元」） -> 「总计：100元」
+		"拼接": {
+			Name: "拼接",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				var sb strings.Builder
+				sb.WriteString(this.Value)
+				for _, param := range params {
+					sb.WriteString(textOf(param))
+				}
+				return NewZnString(sb.String()), nil
+			},
+		},
+		// 「甲、乙、丙」之（分割：「、」） -> 【「甲」，「乙」，「丙」】
+		// if separator is empty, split the string into characters.
+		"分割": {
+			Name: "分割",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				args, err := getStringParams(params, 1, 1)
+				if err != nil {
+					return nil, err
+				}
+				items := []ZnValue{}
+				for _, item := range strings.Split(this.Value, args[0]) {
+					items = append(items, NewZnString(item))
+				}
+				return NewZnArray(items), nil
+			},
+		},
+		// 替换：旧文本，新文本 - replace all matches
+		"替换": {
+			Name: "替换",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				args, err := getStringParams(params, 2, 2)
+				if err != nil {
+					return nil, err
+				}
+				return NewZnString(strings.ReplaceAll(this.Value, args[0], args[1])), nil
+			},
+		},
+		// get the (character) index of first match, return -1 if not found
+		"查找": {
+			Name: "查找",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				args, err := getStringParams(params, 1, 1)
+				if err != nil {
+					return nil, err
+				}
+				idx := strings.Index(this.Value, args[0])
+				if idx >= 0 {
+					idx = utf8.RuneCountInString(this.Value[:idx])
+				}
+				return NewZnDecimalFromInt(idx, 0), nil
+			},
+		},
+		// 「中华人民共和国」之（截取：2，4） -> 「人民」; the end index could be omitted.
+		"截取": {
+			Name: "截取",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) == 0 {
+					return nil, error.LeastParamsError(1)
+				}
+				if len(params) > 2 {
+					return nil, error.MostParamsError(2)
+				}
+				chars := []rune(this.Value)
+				start, err := getIndexParam(params[0], len(chars))
+				if err != nil {
+					return nil, err
+				}
+				end := len(chars)
+				if len(params) == 2 {
+					if end, err = getIndexParam(params[1], len(chars)); err != nil {
+						return nil, err
+					}
+				}
+				if start > end {
+					return nil, error.IndexOutOfRange()
+				}
+				return NewZnString(string(chars[start:end])), nil
+			},
+		},
+		// trim leading & trailing whitespaces (including full-width spaces)
+		"去空白": {
+			Name: "去空白",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				return NewZnString(strings.TrimSpace(this.Value)), nil
+			},
+		},
+		"大写": {
+			Name: "大写",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				return NewZnString(strings.ToUpper(this.Value)), nil
+			},
+		},
+		"小写": {
+			Name: "小写",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				return NewZnString(strings.ToLower(this.Value)), nil
+			},
+		},
+		"包含": {
+			Name: "包含",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				args, err := getStringParams(params, 1, 1)
+				if err != nil {
+					return nil, err
+				}
+				return NewZnBool(strings.Contains(this.Value, args[0])), nil
+			},
+		},
+		// NOTICE: since 以 is a keyword, 「以…开头」 is named as 「开头是」 instead.
+		"开头是": {
+			Name: "开头是",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				args, err := getStringParams(params, 1, 1)
+				if err != nil {
+					return nil, err
+				}
+				return NewZnBool(strings.HasPrefix(this.Value, args[0])), nil
+			},
+		},
+		"结尾是": {
+			Name: "结尾是",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				args, err := getStringParams(params, 1, 1)
+				if err != nil {
+					return nil, err
+				}
+				return NewZnBool(strings.HasSuffix(this.Value, args[0])), nil
+			},
+		},
+		// 「哈」之（重复：3） -> 「哈哈哈」
+		"重复": {
+			Name: "重复",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnString)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 1 {
+					return nil, error.ExactParamsError(1)
+				}
+				v, ok := params[0].(*ZnDecimal)
+				if !ok {
+					return nil, error.InvalidParamType("integer")
+				}
+				count, err := v.asInteger()
+				if err != nil || count < 0 {
+					return nil, error.InvalidParamType("integer")
+				}
+				return NewZnString(strings.Repeat(this.Value, count)), nil
+			},
+		},
+	}
+}
+
+// getStringParams - assert all params are strings, and the number of them is in range [min, max]
+func getStringParams(params []ZnValue, min int, max int) ([]string, *error.Error) {
+	if len(params) < min {
+		return nil, error.LeastParamsError(min)
+	}
+	if len(params) > max {
+		return nil, error.MostParamsError(max)
+	}
+	args := []string{}
+	for _, param := range params {
+		v, ok := param.(*ZnString)
+		if !ok {
+			return nil, error.InvalidParamType("string")
+		}
+		args = append(args, v.Value)
+	}
+	return args, nil
+}
+
+// textOf - get the text of a value for joining strings; unlike String(),
+// the content of a string will not be quoted by 「」.
+func textOf(val ZnValue) string {
+	if v, ok := val.(*ZnString); ok {
+		return v.Value
+	}
+	return val.String()
+}
+
+var defaultHashMapClassRef = &ClassRef{
+	Name: "列表",
+	Constructor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		return NewZnNull(), nil
+	},
+}
+
+// getters & methods of hashmaps are assigned on init() to avoid initialization cycle.
+// All of them keep the insertion order of keys.
+func init() {
+	// getters without params are also available as methods, e.g. 列表之数目 or 列表之（数目）
+	keysClosure := &ClosureRef{
+		Name: "键列表",
+		Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnHashMap)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			keys := []ZnValue{}
+			for _, key := range this.KeyOrder {
+				keys = append(keys, NewZnString(key))
+			}
+			return NewZnArray(keys), nil
+		},
+	}
+	valuesClosure := &ClosureRef{
+		Name: "值列表",
+		Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnHashMap)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			values := []ZnValue{}
+			for _, key := range this.KeyOrder {
+				values = append(values, this.Value[key])
+			}
+			return NewZnArray(values), nil
+		},
+	}
+	countClosure := &ClosureRef{
+		Name: "数目",
+		Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnHashMap)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return NewZnDecimalFromInt(len(this.KeyOrder), 0), nil
+		},
+	}
+
+	defaultHashMapClassRef.GetterList = map[string]*ClosureRef{
+		"键列表": keysClosure,
+		"值列表": valuesClosure,
+		"数目":  countClosure,
+	}
+	defaultHashMapClassRef.MethodList = map[string]*ClosureRef{
+		"键列表": keysClosure,
+		"值列表": valuesClosure,
+		"数目":  countClosure,
+		"含有": {
+			Name: "含有",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnHashMap)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 1 {
+					return nil, error.ExactParamsError(1)
+				}
+				key, ok := getHashMapKey(params[0])
+				if !ok {
+					return nil, error.InvalidParamType("integer", "string")
+				}
+				_, exists := this.Value[key]
+				return NewZnBool(exists), nil
+			},
+		},
+		// remove the key and return its value
+		"删除": {
+			Name: "删除",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnHashMap)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) != 1 {
+					return nil, error.ExactParamsError(1)
+				}
+				key, ok := getHashMapKey(params[0])
+				if !ok {
+					return nil, error.InvalidParamType("integer", "string")
+				}
+				val, exists := this.Value[key]
+				if !exists {
+					return nil, error.IndexKeyNotFound(key)
+				}
+				this.DeleteValue(key)
+				return val, nil
+			},
+		},
+		// merge other hashmaps into this one (in place); values of existing keys are overwritten,
+		// while new keys are appended by their order.
+		"合并": {
+			Name: "合并",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnHashMap)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) == 0 {
+					return nil, error.LeastParamsError(1)
+				}
+				for _, param := range params {
+					other, ok := param.(*ZnHashMap)
+					if !ok {
+						return nil, error.InvalidParamType("hashmap")
+					}
+					for _, key := range other.KeyOrder {
+						this.SetValue(key, other.Value[key])
+					}
+				}
+				return this, nil
+			},
+		},
+		// 获取：键，默认值 - returns the default value (or 空 if not given) when the key doesn't exist
+		"获取": {
+			Name: "获取",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnHashMap)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				if len(params) == 0 {
+					return nil, error.LeastParamsError(1)
+				}
+				if len(params) > 2 {
+					return nil, error.MostParamsError(2)
+				}
+				key, ok := getHashMapKey(params[0])
+				if !ok {
+					return nil, error.InvalidParamType("integer", "string")
+				}
+				if val, exists := this.Value[key]; exists {
+					return val, nil
+				}
+				if len(params) == 2 {
+					return params[1], nil
+				}
+				return NewZnNull(), nil
+			},
+		},
+	}
+}
+
+var defaultDateClassRef = &ClassRef{
+	Name: "日期",
+	Constructor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		return NewZnNull(), nil
+	},
+}
+
+var defaultTimeClassRef = &ClassRef{
+	Name: "时刻",
+	Constructor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		return NewZnNull(), nil
+	},
+}
+
+var defaultDurationClassRef = &ClassRef{
+	Name: "时长",
+	Constructor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		return NewZnNull(), nil
+	},
+}
+
+// calendarFields - fields shared by dates & times, e.g. 日期之年，时刻之星期
+var calendarFields = map[string]func(t time.Time) int{
+	"年":  func(t time.Time) int { return t.Year() },
+	"月":  func(t time.Time) int { return int(t.Month()) },
+	"日":  func(t time.Time) int { return t.Day() },
+	"星期": isoWeekday,
+}
+
+// clockFields - fields of times only, e.g. 时刻之时
+var clockFields = map[string]func(t time.Time) int{
+	"时": func(t time.Time) int { return t.Hour() },
+	"分": func(t time.Time) int { return t.Minute() },
+	"秒": func(t time.Time) int { return t.Second() },
+}
+
+func getDateParam(param ZnValue) (*ZnDate, *error.Error) {
+	v, ok := param.(*ZnDate)
+	if !ok {
+		return nil, error.InvalidParamType("date")
+	}
+	return v, nil
+}
+
+func getTimeParam(param ZnValue) (*ZnTime, *error.Error) {
+	v, ok := param.(*ZnTime)
+	if !ok {
+		return nil, error.InvalidParamType("time")
+	}
+	return v, nil
+}
+
+func getDurationParam(param ZnValue) (*ZnDuration, *error.Error) {
+	v, ok := param.(*ZnDuration)
+	if !ok {
+		return nil, error.InvalidParamType("duration")
+	}
+	return v, nil
+}
+
+// getTimeZoneParam - get timezone from the (optional) param; if not given, returns the default timezone of context
+func getTimeZoneParam(ctx *Context, params []ZnValue, idx int) (*time.Location, *error.Error) {
+	if len(params) <= idx {
+		return ctx.location, nil
+	}
+	v, ok := params[idx].(*ZnString)
+	if !ok {
+		return nil, error.InvalidParamType("string")
+	}
+	return GetTimeZone(v.Value)
+}
+
+// getters & methods of dates, times & durations are assigned on init() to avoid initialization cycle.
+func init() {
+	timeFieldGetter := func(name string, field func(t time.Time) int) *ClosureRef {
+		return NewNativeClosureRef(name, func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return NewZnDecimalFromInt(field(this.Value), 0), nil
+		})
+	}
+	defaultDateClassRef.GetterList = map[string]*ClosureRef{}
+	defaultTimeClassRef.GetterList = map[string]*ClosureRef{}
+	for name, field := range calendarFields {
+		name, field := name, field
+		defaultDateClassRef.GetterList[name] = NewNativeClosureRef(name, func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDate)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return NewZnDecimalFromInt(field(this.Value), 0), nil
+		})
+		defaultTimeClassRef.GetterList[name] = timeFieldGetter(name, field)
+	}
+	for name, field := range clockFields {
+		defaultTimeClassRef.GetterList[name] = timeFieldGetter(name, field)
+	}
+
+	//// 日期
+	dateGetters := map[string]FuncExecutor{
+		"文本": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDate)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return NewZnString(this.String()), nil
+		},
+		// Monday ~ Friday
+		"是工作日": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDate)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return NewZnBool(isWorkday(this.Value)), nil
+		},
+	}
+	for name, executor := range dateGetters {
+		defaultDateClassRef.GetterList[name] = NewNativeClosureRef(name, executor)
+	}
+
+	// addDateExecutor - 加天数，加月数，加年数，加工作日
+	addDateExecutor := func(add func(t time.Time, n int) time.Time) FuncExecutor {
+		return func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDate)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) != 1 {
+				return nil, error.ExactParamsError(1)
+			}
+			n, err := getIntegerParam(params[0])
+			if err != nil {
+				return nil, err
+			}
+			if n > maxDateOffset || n < -maxDateOffset {
+				return nil, error.DateOffsetOutOfRange(n, maxDateOffset)
+			}
+			t := add(this.Value, n)
+			return NewZnDate(t.Year(), int(t.Month()), t.Day()), nil
+		}
+	}
+	dateMethods := map[string]FuncExecutor{
+		"加天数":  addDateExecutor(func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) }),
+		"加月数":  addDateExecutor(addMonths),
+		"加年数":  addDateExecutor(func(t time.Time, n int) time.Time { return addMonths(t, n*12) }),
+		"加工作日": addDateExecutor(addWorkdays),
+		// 相差天数：另一日期 - days from another date to this date
+		"相差天数": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDate)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) != 1 {
+				return nil, error.ExactParamsError(1)
+			}
+			other, err := getDateParam(params[0])
+			if err != nil {
+				return nil, err
+			}
+			return NewZnDecimalFromInt(daysBetween(this.Value, other.Value), 0), nil
+		},
+		// 周岁：日期 - full years from this date (as birthday) to the given date (today by default)
+		"周岁": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDate)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) > 1 {
+				return nil, error.MostParamsError(1)
+			}
+			date := ctx.now()
+			if len(params) == 1 {
+				other, err := getDateParam(params[0])
+				if err != nil {
+					return nil, err
+				}
+				date = other.Value
+			}
+			return NewZnDecimalFromInt(fullYearsBetween(this.Value, date), 0), nil
+		},
+		"格式化": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDate)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			strs, err := getStringParams(params, 1, 1)
+			if err != nil {
+				return nil, err
+			}
+			return NewZnString(formatTime(this.Value, strs[0])), nil
+		},
+	}
+	defaultDateClassRef.MethodList = map[string]*ClosureRef{}
+	for name, executor := range dateMethods {
+		defaultDateClassRef.MethodList[name] = NewNativeClosureRef(name, executor)
+	}
+	// 生日之周岁 - age as of today
+	defaultDateClassRef.GetterList["周岁"] = defaultDateClassRef.MethodList["周岁"]
+
+	//// 时刻
+	timeGetters := map[string]FuncExecutor{
+		"文本": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return NewZnString(this.String()), nil
+		},
+		"日期": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			t := this.Value
+			return NewZnDate(t.Year(), int(t.Month()), t.Day()), nil
+		},
+		// name of timezone, e.g. 「Asia/Shanghai」
+		"时区": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return NewZnString(this.Value.Location().String()), nil
+		},
+		// unix timestamp (in seconds)
+		"时间戳": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return NewZnDecimal(strconv.FormatInt(this.Value.Unix(), 10))
+		},
+	}
+	for name, executor := range timeGetters {
+		defaultTimeClassRef.GetterList[name] = NewNativeClosureRef(name, executor)
+	}
+
+	// addTimeExecutor - 加天数，加月数
+	addTimeExecutor := func(add func(t time.Time, n int) time.Time) FuncExecutor {
+		return func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) != 1 {
+				return nil, error.ExactParamsError(1)
+			}
+			n, err := getIntegerParam(params[0])
+			if err != nil {
+				return nil, err
+			}
+			if n > maxDateOffset || n < -maxDateOffset {
+				return nil, error.DateOffsetOutOfRange(n, maxDateOffset)
+			}
+			return NewZnTime(add(this.Value, n)), nil
+		}
+	}
+	timeMethods := map[string]FuncExecutor{
+		"加时长": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) != 1 {
+				return nil, error.ExactParamsError(1)
+			}
+			d, err := getDurationParam(params[0])
+			if err != nil {
+				return nil, err
+			}
+			return NewZnTime(this.Value.Add(d.Value)), nil
+		},
+		"加天数": addTimeExecutor(func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) }),
+		"加月数": addTimeExecutor(addMonths),
+		// 相差：另一时刻 - duration from another time to this time
+		"相差": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) != 1 {
+				return nil, error.ExactParamsError(1)
+			}
+			other, err := getTimeParam(params[0])
+			if err != nil {
+				return nil, err
+			}
+			// time.Sub saturates to the max (or min) duration on overflow
+			d := this.Value.Sub(other.Value)
+			if d == math.MaxInt64 || d == math.MinInt64 {
+				return nil, error.DurationOutOfRange(fmt.Sprintf("%s － %s", this.String(), other.String()))
+			}
+			return NewZnDuration(d), nil
+		},
+		// 转换时区：「Asia/Tokyo」 - the same instant under another timezone
+		"转换时区": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) != 1 {
+				return nil, error.ExactParamsError(1)
+			}
+			loc, err := getTimeZoneParam(ctx, params, 0)
+			if err != nil {
+				return nil, err
+			}
+			return NewZnTime(this.Value.In(loc)), nil
+		},
+		"格式化": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnTime)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			strs, err := getStringParams(params, 1, 1)
+			if err != nil {
+				return nil, err
+			}
+			return NewZnString(formatTime(this.Value, strs[0])), nil
+		},
+	}
+	defaultTimeClassRef.MethodList = map[string]*ClosureRef{}
+	for name, executor := range timeMethods {
+		defaultTimeClassRef.MethodList[name] = NewNativeClosureRef(name, executor)
+	}
+
+	//// 时长
+	defaultDurationClassRef.GetterList = map[string]*ClosureRef{
+		"文本": NewNativeClosureRef("文本", func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDuration)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return NewZnString(this.String()), nil
+		}),
+	}
+	// 天数，小时数，分钟数，秒数 - the duration in given unit as a decimal, e.g. 90分钟之小时数 -> 1.5
+	durationInUnits := map[string]time.Duration{
+		"天数":  24 * time.Hour,
+		"小时数": time.Hour,
+		"分钟数": time.Minute,
+		"秒数":  time.Second,
+	}
+	for name, unit := range durationInUnits {
+		name, unit := name, unit
+		defaultDurationClassRef.GetterList[name] = NewNativeClosureRef(name, func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDuration)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return newDecimalFromDuration(getArith(ctx, scope), this.Value, unit)
+		})
+	}
+
+	// calcDurationExecutor - 加，减
+	calcDurationExecutor := func(op string, calc func(a time.Duration, b time.Duration) (time.Duration, bool)) FuncExecutor {
+		return func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnDuration)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) != 1 {
+				return nil, error.ExactParamsError(1)
+			}
+			d, err := getDurationParam(params[0])
+			if err != nil {
+				return nil, err
+			}
+			result, ok := calc(this.Value, d.Value)
+			if !ok {
+				return nil, error.DurationOutOfRange(fmt.Sprintf("%s %s %s", this.String(), op, d.String()))
+			}
+			return NewZnDuration(result), nil
+		}
+	}
+	defaultDurationClassRef.MethodList = map[string]*ClosureRef{
+		"加": NewNativeClosureRef("加", calcDurationExecutor("＋", addDurations)),
+		"减": NewNativeClosureRef("减", calcDurationExecutor("－", subDurations)),
+	}
+}
+
+// （日期：「2024年3月5日」） or （日期：2024，3，5）
+var newDateExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+	if len(params) == 1 {
+		if v, ok := params[0].(*ZnString); ok {
+			return ParseZnDate(v.Value)
+		}
+		if v, ok := params[0].(*ZnDate); ok {
+			return v, nil
+		}
+		return nil, error.InvalidParamType("string", "date")
+	}
+	if len(params) != 3 {
+		return nil, error.ExactParamsError(3)
+	}
+	var fields [6]int
+	for idx, param := range params {
+		n, err := getIntegerParam(param)
+		if err != nil {
+			return nil, err
+		}
+		fields[idx] = n
+	}
+	if _, ok := newDateTime(fields, time.UTC); !ok {
+		return nil, error.InvalidDateTime(fmt.Sprintf("%d年%d月%d日", fields[0], fields[1], fields[2]))
+	}
+	return NewZnDate(fields[0], fields[1], fields[2]), nil
+}
+
+// （时刻：「2024年3月5日 14:30」，时区） or （时刻：2024，3，5，14，30，0，时区）
+// timezone is optional, the default timezone of context is used if not given.
+var newTimeExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+	if len(params) == 0 {
+		return nil, error.LeastParamsError(1)
+	}
+	switch v := params[0].(type) {
+	case *ZnString:
+		if len(params) > 2 {
+			return nil, error.MostParamsError(2)
+		}
+		loc, err := getTimeZoneParam(ctx, params, 1)
+		if err != nil {
+			return nil, err
+		}
+		return ParseZnTime(v.Value, loc)
+	case *ZnDate:
+		// the beginning of the day, e.g. （时刻：今天之日期，「UTC」）
+		if len(params) > 2 {
+			return nil, error.MostParamsError(2)
+		}
+		loc, err := getTimeZoneParam(ctx, params, 1)
+		if err != nil {
+			return nil, err
+		}
+		t := v.Value
+		return NewZnTime(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)), nil
+	}
+
+	// year, month, day, [hour, minute, second], [timezone]
+	var fields [6]int
+	var numParams = params
+	if _, ok := params[len(params)-1].(*ZnString); ok {
+		numParams = params[:len(params)-1]
+	}
+	if len(numParams) < 3 {
+		return nil, error.LeastParamsError(3)
+	}
+	if len(numParams) > 6 {
+		return nil, error.MostParamsError(7)
+	}
+	for idx, param := range numParams {
+		n, err := getIntegerParam(param)
+		if err != nil {
+			return nil, err
+		}
+		fields[idx] = n
+	}
+	loc, err := getTimeZoneParam(ctx, params, len(numParams))
+	if err != nil {
+		return nil, err
+	}
+	t, ok := newDateTime(fields, loc)
+	if !ok {
+		return nil, error.InvalidDateTime(fmt.Sprintf("%d年%d月%d日 %d:%d:%d", fields[0], fields[1], fields[2], fields[3], fields[4], fields[5]))
+	}
+	return NewZnTime(t), nil
+}
+
+// （时长：「3天4小时」） or （时长：90，「分钟」）
+var newDurationExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+	switch len(params) {
+	case 1:
+		v, ok := params[0].(*ZnString)
+		if !ok {
+			return nil, error.InvalidParamType("string")
+		}
+		return ParseZnDuration(v.Value)
+	case 2:
+		num, ok := params[0].(*ZnDecimal)
+		if !ok {
+			return nil, error.InvalidParamType("decimal")
+		}
+		unit, ok := params[1].(*ZnString)
+		if !ok {
+			return nil, error.InvalidParamType("string")
+		}
+		return newDurationFromDecimal(num, unit.Value)
+	}
+	return nil, error.MostParamsError(2)
+}
+
+// （现在） - current time under the default timezone
+var nowExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+	if len(params) > 0 {
+		return nil, error.ExactParamsError(0)
+	}
+	return NewZnTime(ctx.now()), nil
+}
+
+// （今天） - current date under the default timezone
+var todayExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+	if len(params) > 0 {
+		return nil, error.ExactParamsError(0)
+	}
+	t := ctx.now()
+	return NewZnDate(t.Year(), int(t.Month()), t.Day()), nil
+}
+
+var defaultMoneyClassRef = &ClassRef{
+	Name: "金额",
+	Constructor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		return NewZnNull(), nil
+	},
+}
+
+// getters & methods of money are assigned on init() to avoid initialization cycle.
+func init() {
+	moneyGetters := map[string]func(this *ZnMoney) ZnValue{
+		// e.g. 「CNY」
+		"币种":   func(this *ZnMoney) ZnValue { return NewZnString(this.Currency.Code) },
+		"币种名称": func(this *ZnMoney) ZnValue { return NewZnString(this.Currency.Name) },
+		"数额":   func(this *ZnMoney) ZnValue { return copyZnDecimal(this.Amount) },
+		"文本":   func(this *ZnMoney) ZnValue { return NewZnString(this.String()) },
+	}
+	defaultMoneyClassRef.GetterList = map[string]*ClosureRef{}
+	for name, getter := range moneyGetters {
+		getter := getter
+		defaultMoneyClassRef.GetterList[name] = NewNativeClosureRef(name, func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnMoney)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return getter(this), nil
+		})
+	}
+
+	// allocate - allocate the money by ratios with the rounding mode of current scope
+	allocate := func(ctx *Context, scope *FuncScope, this *ZnMoney, ratios []*ZnDecimal) (ZnValue, *error.Error) {
+		arith := getArith(ctx, scope)
+		parts, err := allocateMoney(arith, this, ratios, arith.GetRoundingMode())
+		if err != nil {
+			return nil, err
+		}
+		values := []ZnValue{}
+		for _, part := range parts {
+			values = append(values, part)
+		}
+		return NewZnArray(values), nil
+	}
+	moneyMethods := map[string]FuncExecutor{
+		// 舍入：舍入模式 - round to minor units (e.g. 分), the rounding mode is decided by current scope by default
+		"舍入": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnMoney)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) > 1 {
+				return nil, error.MostParamsError(1)
+			}
+			arith := getArith(ctx, scope)
+			mode := arith.GetRoundingMode()
+			if len(params) == 1 {
+				m, err := getRoundingModeParam(params[0])
+				if err != nil {
+					return nil, err
+				}
+				mode = m
+			}
+			return roundMoney(arith, this, mode)
+		},
+		// 按比例分配：3，2，1 or 按比例分配：【3，2，1】
+		"按比例分配": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnMoney)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) == 1 {
+				if arr, ok := params[0].(*ZnArray); ok {
+					params = arr.Value
+				}
+			}
+			ratios, err := getDecimalParams(params)
+			if err != nil {
+				return nil, err
+			}
+			return allocate(ctx, scope, this, ratios)
+		},
+		// 平分：份数
+		"平分": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnMoney)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) != 1 {
+				return nil, error.ExactParamsError(1)
+			}
+			n, err := getIntegerParam(params[0])
+			if err != nil {
+				return nil, err
+			}
+			if n <= 0 || n > maxAllocateParts {
+				return nil, error.PartsOutOfRange(n, maxAllocateParts)
+			}
+			ratios := []*ZnDecimal{}
+			for i := 0; i < n; i++ {
+				ratios = append(ratios, NewZnDecimalFromInt(1, 0))
+			}
+			return allocate(ctx, scope, this, ratios)
+		},
+		// 换算：汇率，币种 - e.g. 100美元之（换算：7.1，「人民币」） -> ¥710.00 (not rounded)
+		"换算": func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnMoney)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) != 2 {
+				return nil, error.ExactParamsError(2)
+			}
+			rate, ok := params[0].(*ZnDecimal)
+			if !ok {
+				return nil, error.InvalidParamType("decimal")
+			}
+			name, ok := params[1].(*ZnString)
+			if !ok {
+				return nil, error.InvalidParamType("string")
+			}
+			currency, err := GetCurrency(name.Value)
+			if err != nil {
+				return nil, err
+			}
+			return NewZnMoney(getArith(ctx, scope).Mul(this.Amount, rate), currency), nil
+		},
+	}
+	defaultMoneyClassRef.MethodList = map[string]*ClosureRef{}
+	for name, executor := range moneyMethods {
+		defaultMoneyClassRef.MethodList[name] = NewNativeClosureRef(name, executor)
+	}
+}
+
+// （货币：12.5，「美元」） - the currency could be its code, name or unit; 「人民币」 by default
+var newMoneyExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+	if len(params) == 0 {
+		return nil, error.LeastParamsError(1)
+	}
+	if len(params) > 2 {
+		return nil, error.MostParamsError(2)
+	}
+	amount, ok := params[0].(*ZnDecimal)
+	if !ok {
+		return nil, error.InvalidParamType("decimal")
+	}
+	currency := Currencies["CNY"]
+	if len(params) == 2 {
+		name, ok := params[1].(*ZnString)
+		if !ok {
+			return nil, error.InvalidParamType("string")
+		}
+		c, err := GetCurrency(name.Value)
+		if err != nil {
+			return nil, err
+		}
+		currency = c
+	}
+	return NewZnMoney(copyZnDecimal(amount), currency), nil
+}
+
+var defaultFractionClassRef = &ClassRef{
+	Name: "分数",
+	Constructor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		return NewZnNull(), nil
+	},
+}
+
+// getters & methods of fractions are assigned on init() to avoid initialization cycle.
+func init() {
+	fractionGetters := map[string]func(this *ZnFraction) ZnValue{
+		"分子":  func(this *ZnFraction) ZnValue { return newDecimalFromBigInt(this.Value.Num()) },
+		"分母":  func(this *ZnFraction) ZnValue { return newDecimalFromBigInt(this.Value.Denom()) },
+		"是整数": func(this *ZnFraction) ZnValue { return NewZnBool(this.Value.IsInt()) },
+		"文本":  func(this *ZnFraction) ZnValue { return NewZnString(this.String()) },
+	}
+	defaultFractionClassRef.GetterList = map[string]*ClosureRef{}
+	for name, getter := range fractionGetters {
+		getter := getter
+		defaultFractionClassRef.GetterList[name] = NewNativeClosureRef(name, func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnFraction)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			return getter(this), nil
+		})
+	}
+
+	defaultFractionClassRef.MethodList = map[string]*ClosureRef{
+		// 近似值：位数，舍入模式 - the rounding mode is decided by current scope if not given
+		"近似值": NewNativeClosureRef("近似值", func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+			this, ok := scope.GetTargetThis().(*ZnFraction)
+			if !ok {
+				return nil, error.NewErrorSLOT("invalid object type")
+			}
+			if len(params) == 0 {
+				return nil, error.LeastParamsError(1)
+			}
+			if len(params) > 2 {
+				return nil, error.MostParamsError(2)
+			}
+			places, err := getIntegerParam(params[0])
+			if err != nil {
+				return nil, err
+			}
+			mode := getArith(ctx, scope).GetRoundingMode()
+			if len(params) == 2 {
+				if mode, err = getRoundingModeParam(params[1]); err != nil {
+					return nil, err
+				}
+			}
+			return roundRat(this.Value, places, mode)
+		}),
+	}
+}
+
+// （分数：1，3），（分数：「1/3」） or （分数：0.25）
+var newFractionExecutor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+	switch len(params) {
+	case 1:
+		switch v := params[0].(type) {
+		case *ZnString:
+			return ParseZnFraction(v.Value)
+		case *ZnDecimal:
+			r, err := ratFromDecimal(v)
+			if err != nil {
+				return nil, err
+			}
+			return NewZnFraction(r), nil
+		case *ZnFraction:
+			return v, nil
+		}
+		return nil, error.InvalidParamType("string", "decimal")
+	case 2:
+		decimals, err := getDecimalParams(params)
+		if err != nil {
+			return nil, err
+		}
+		r, err := quoDecimals(decimals[0], decimals[1])
+		if err != nil {
+			return nil, err
+		}
+		return NewZnFraction(r), nil
+	}
+	if len(params) == 0 {
+		return nil, error.LeastParamsError(1)
+	}
+	return nil, error.MostParamsError(2)
+}
+
+var defaultExceptionClassRef = &ClassRef{
+	Name: "异常",
+	Constructor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		return NewZnNull(), nil
+	},
+	GetterList: map[string]*ClosureRef{
+		// error code, e.g. 「2501」
+		"代码": {
+			Name: "代码",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnException)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				return NewZnString(fmt.Sprintf("%04X", this.Err.GetCode())), nil
+			},
+		},
+		// error class name, e.g. 「标识错误」
+		"类别": {
+			Name: "类别",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnException)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				return NewZnString(this.Err.GetErrorClassName()), nil
+			},
+		},
+		"信息": {
+			Name: "信息",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnException)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				return NewZnString(this.Err.Error()), nil
+			},
+		},
+		// additional info of the error (from GetInfo()) as a hashmap
+		"详情": {
+			Name: "详情",
+			Executor: func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+				this, ok := scope.GetTargetThis().(*ZnException)
+				if !ok {
+					return nil, error.NewErrorSLOT("invalid object type")
+				}
+				info := this.Err.GetInfo()
+				keys := []string{}
+				for k := range info {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+
+				kvPairs := []KVPair{}
+				for _, k := range keys {
+					kvPairs = append(kvPairs, KVPair{
+						Key:   k,
+						Value: NewZnString(info[k]),
+					})
+				}
+				return NewZnHashMap(kvPairs), nil
+			},
+		},
+	},
+}
+
+// newPredefinedValues - predefined values are those variables (symbols) defined before
+// any execution procedure. A new set of values is created for each Context, so that
+// values are never shared between contexts.
+// NOTICE: those variables are all constants!
+func newPredefinedValues() map[string]ZnValue {
+	return map[string]ZnValue{
 		"真":       NewZnBool(true),
 		"假":       NewZnBool(false),
 		"空":       NewZnNull(),
@@ -254,5 +2167,23 @@ func init() {
 		"X/Y":     NewZnNativeFunction("X/Y", divValueExecutor),
 		"求商":      NewZnNativeFunction("X/Y", divValueExecutor),
 		"__probe": NewZnNativeFunction("__probe", probeExecutor),
+		"四舍五入":    decimalMathFuncs["四舍五入"].asFunction("四舍五入"),
+		"取整":      decimalMathFuncs["取整"].asFunction("取整"),
+		"求绝对值":    decimalMathFuncs["绝对值"].asFunction("求绝对值"),
+		"求余":      decimalMathFuncs["取余"].asFunction("求余"),
+		"整除":      decimalMathFuncs["整除"].asFunction("整除"),
+		"乘方":      decimalMathFuncs["乘方"].asFunction("乘方"),
+		"开方":      decimalMathFuncs["开方"].asFunction("开方"),
+		"求最大值":    decimalMathFuncs["最大值"].asFunction("求最大值"),
+		"求最小值":    decimalMathFuncs["最小值"].asFunction("求最小值"),
+		"比较大小":    decimalMathFuncs["比较"].asFunction("比较大小"),
+		"日期":      NewZnNativeFunction("日期", newDateExecutor),
+		"时刻":      NewZnNativeFunction("时刻", newTimeExecutor),
+		"时长":      NewZnNativeFunction("时长", newDurationExecutor),
+		"现在":      NewZnNativeFunction("现在", nowExecutor),
+		"今天":      NewZnNativeFunction("今天", todayExecutor),
+		"货币":      NewZnNativeFunction("货币", newMoneyExecutor),
+		"分数":      NewZnNativeFunction("分数", newFractionExecutor),
+		"精确除":     decimalMathFuncs["精确除"].asFunction("精确除"),
 	}
 }
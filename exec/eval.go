@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/reg0007/Zn/error"
 	"github.com/reg0007/Zn/syntax"
@@ -67,6 +68,8 @@ func duplicateValue(in ZnValue) ZnValue {
 		}
 	case *ZnFunction: // function itself is immutable, so return directly
 		return in
+	case *ZnDate, *ZnTime, *ZnDuration, *ZnMoney, *ZnFraction: // temporal values, money & fractions are immutable as well
+		return in
 	case *ZnObject:
 		newPropList := map[string]ZnValue{}
 
@@ -130,12 +133,85 @@ func compareValues(left ZnValue, right ZnValue, verb compareVerb) (bool, *error.
 			}
 			return cmpResult, nil
 		}
+		// compare with fraction exactly, e.g. 0.5 等于 1/2
+		if vr, ok := right.(*ZnFraction); ok {
+			rl, err := ratFromDecimal(vl)
+			if err != nil {
+				return false, err
+			}
+			return compareRats(rl, vr.Value, verb)
+		}
 		// if vert == CmbEq and rightValue is not decimal type
 		// then return `false` directly
 		if verb == CmpEq {
 			return false, nil
 		}
 		return false, error.InvalidCompareRType("decimal")
+	case *ZnDate:
+		vr, ok := right.(*ZnDate)
+		if !ok {
+			if verb == CmpEq {
+				return false, nil
+			}
+			return false, error.InvalidCompareRType("date")
+		}
+		return compareTimes(vl.Value, vr.Value, verb)
+	case *ZnTime:
+		vr, ok := right.(*ZnTime)
+		if !ok {
+			if verb == CmpEq {
+				return false, nil
+			}
+			return false, error.InvalidCompareRType("time")
+		}
+		return compareTimes(vl.Value, vr.Value, verb)
+	case *ZnDuration:
+		vr, ok := right.(*ZnDuration)
+		if !ok {
+			if verb == CmpEq {
+				return false, nil
+			}
+			return false, error.InvalidCompareRType("duration")
+		}
+		switch verb {
+		case CmpEq:
+			return vl.Value == vr.Value, nil
+		case CmpLt:
+			return vl.Value < vr.Value, nil
+		case CmpGt:
+			return vl.Value > vr.Value, nil
+		}
+		return false, error.UnExpectedCase("比较原语", strconv.Itoa(int(verb)))
+	case *ZnFraction:
+		switch vr := right.(type) {
+		case *ZnFraction:
+			return compareRats(vl.Value, vr.Value, verb)
+		case *ZnDecimal:
+			rr, err := ratFromDecimal(vr)
+			if err != nil {
+				return false, err
+			}
+			return compareRats(vl.Value, rr, verb)
+		}
+		if verb == CmpEq {
+			return false, nil
+		}
+		return false, error.InvalidCompareRType("decimal", "fraction")
+	case *ZnMoney:
+		vr, ok := right.(*ZnMoney)
+		if !ok {
+			if verb == CmpEq {
+				return false, nil
+			}
+			return false, error.InvalidCompareRType("money")
+		}
+		if vl.Currency != vr.Currency {
+			if verb == CmpEq {
+				return false, nil
+			}
+			return false, error.CurrencyMismatch(vl.Currency.Code, vr.Currency.Code)
+		}
+		return compareValues(vl.Amount, vr.Amount, verb)
 	case *ZnString:
 		// Only CmpEq is valid for comparison
 		if verb != CmpEq {
@@ -172,7 +248,9 @@ func compareValues(left ZnValue, right ZnValue, verb compareVerb) (bool, *error.
 				if err != nil {
 					return false, err
 				}
-				return cmpVal, nil
+				if !cmpVal {
+					return false, nil
+				}
 			}
 			return true, nil
 		}
@@ -197,7 +275,9 @@ func compareValues(left ZnValue, right ZnValue, verb compareVerb) (bool, *error.
 				if err != nil {
 					return false, err
 				}
-				return cmpVal, nil
+				if !cmpVal {
+					return false, nil
+				}
 			}
 			return true, nil
 		}
@@ -206,6 +286,32 @@ func compareValues(left ZnValue, right ZnValue, verb compareVerb) (bool, *error.
 	return false, error.InvalidCompareLType("decimal", "string", "bool", "array", "hashmap")
 }
 
+// compareTimes - compare two instants; dates are regarded as 00:00 UTC of the day
+func compareTimes(left time.Time, right time.Time, verb compareVerb) (bool, *error.Error) {
+	switch verb {
+	case CmpEq:
+		return left.Equal(right), nil
+	case CmpLt:
+		return left.Before(right), nil
+	case CmpGt:
+		return left.After(right), nil
+	}
+	return false, error.UnExpectedCase("比较原语", strconv.Itoa(int(verb)))
+}
+
+// compareRats - compare fractions (or decimals converted to fractions)
+func compareRats(left *big.Rat, right *big.Rat, verb compareVerb) (bool, *error.Error) {
+	switch verb {
+	case CmpEq:
+		return left.Cmp(right) == 0, nil
+	case CmpLt:
+		return left.Cmp(right) < 0, nil
+	case CmpGt:
+		return left.Cmp(right) > 0, nil
+	}
+	return false, error.UnExpectedCase("比较原语", strconv.Itoa(int(verb)))
+}
+
 //// eval program
 func evalProgram(ctx *Context, scope *RootScope, program *syntax.Program) *error.Error {
 	return evalStmtBlock(ctx, scope, program.Content)
@@ -222,7 +328,10 @@ func evalStatement(ctx *Context, scope Scope, stmt syntax.Statement) *error.Erro
 			scope.GetRoot().SetLastValue(NewZnNull())
 		}
 	}()
-	scope.GetRoot().SetCurrentLine(stmt.GetCurrentLine())
+	ctx.setCurrentLine(scope, stmt.GetCurrentLine())
+	if err := ctx.checkStatementLimits(); err != nil {
+		return err
+	}
 	switch v := stmt.(type) {
 	case *syntax.VarDeclareStmt:
 		return evalVarDeclareStmt(ctx, scope, v)
@@ -233,7 +342,7 @@ func evalStatement(ctx *Context, scope Scope, stmt syntax.Statement) *error.Erro
 	case *syntax.EmptyStmt:
 		return nil
 	case *syntax.FunctionDeclareStmt:
-		fn := NewZnFunction(v)
+		fn := NewZnFunction(v, scope)
 		return bindValue(ctx, scope, v.FuncName.GetLiteral(), fn, false)
 	case *syntax.ClassDeclareStmt:
 		sp, ok := scope.(*RootScope)
@@ -243,6 +352,14 @@ func evalStatement(ctx *Context, scope Scope, stmt syntax.Statement) *error.Erro
 		return bindClassRef(ctx, sp, v)
 	case *syntax.IterateStmt:
 		return evalIterateStmt(ctx, scope, v)
+	case *syntax.TryStmt:
+		return evalTryStmt(ctx, scope, v)
+	case *syntax.MatchStmt:
+		return evalMatchStmt(ctx, scope, v)
+	case *syntax.ThrowStmt:
+		return evalThrowStmt(ctx, scope, v)
+	case *syntax.ImportStmt:
+		return evalImportStmt(ctx, scope, v)
 	case *syntax.FunctionReturnStmt:
 		val, err := evalExpression(ctx, scope, v.ReturnExpr)
 		if err != nil {
@@ -297,7 +414,7 @@ func evalVarDeclareStmt(ctx *Context, scope Scope, node *syntax.VarDeclareStmt)
 				vtag := v.GetLiteral()
 				finalObj := duplicateValue(obj)
 
-				if bindValue(ctx, scope, vtag, finalObj, isConst); err != nil {
+				if err := bindValue(ctx, scope, vtag, finalObj, isConst); err != nil {
 					return err
 				}
 			}
@@ -315,7 +432,15 @@ func evalVarDeclareStmt(ctx *Context, scope Scope, node *syntax.VarDeclareStmt)
 func evalNewObjectPart(ctx *Context, scope Scope, node syntax.VDAssignPair) *error.Error {
 	vtag := node.ObjClass.GetLiteral()
 	// get class definition
-	classRef, err := getClassRef(ctx, scope.GetRoot(), vtag)
+	classScope := scope.GetRoot()
+	if node.ObjModule != nil {
+		module, err := getModule(ctx, scope, node.ObjModule.GetLiteral())
+		if err != nil {
+			return err
+		}
+		classScope = module.scope
+	}
+	classRef, err := getClassRef(ctx, classScope, vtag)
 	if err != nil {
 		return err
 	}
@@ -332,14 +457,14 @@ func evalNewObjectPart(ctx *Context, scope Scope, node syntax.VDAssignPair) *err
 	// assign new object to variables
 	for _, v := range node.Variables {
 		vtag := v.GetLiteral()
-		// compose a new object instance
-		fScope := NewFuncScope(scope, nil)
+		// compose a new object instance (under the scope where the class is defined)
+		fScope := NewFuncScope(classScope, nil)
 		finalObj, err := classRef.Construct(ctx, fScope, cParams)
 		if err != nil {
 			return err
 		}
 
-		if bindValue(ctx, scope, vtag, finalObj, false); err != nil {
+		if err := bindValue(ctx, scope, vtag, finalObj, false); err != nil {
 			return err
 		}
 	}
@@ -365,6 +490,7 @@ func evalWhileLoopStmt(ctx *Context, scope Scope, node *syntax.WhileLoopStmt) *e
 			return nil
 		}
 		// #3. stmt block
+		loopScope.resetSymbols()
 		if err := evalStmtBlock(ctx, loopScope, node.LoopBlock); err != nil {
 			if err.GetCode() == error.ContinueBreakSignal {
 				// continue next turn
@@ -392,7 +518,7 @@ func evalStmtBlock(ctx *Context, scope Scope, block *syntax.BlockStmt) *error.Er
 		for _, stmtI := range block.Children {
 			switch v := stmtI.(type) {
 			case *syntax.FunctionDeclareStmt:
-				fn := NewZnFunction(v)
+				fn := NewZnFunction(v, scope)
 				if err := bindValue(ctx, scope, v.FuncName.GetLiteral(), fn, false); err != nil {
 					return err
 				}
@@ -423,6 +549,17 @@ func evalStmtBlock(ctx *Context, scope Scope, block *syntax.BlockStmt) *error.Er
 	return nil
 }
 
+// evalBranchBlock - execute a nested block of 如果，尝试 or 对于 statement. Unlike loops, the block
+// shares variables with current scope; but arith settings (e.g. 此之（精度：2）) made inside the block
+// only take effect until the block ends.
+func evalBranchBlock(ctx *Context, scope Scope, block *syntax.BlockStmt) *error.Error {
+	if as, ok := scope.(arithScope); ok {
+		saved := as.getArith()
+		defer as.setArith(saved)
+	}
+	return evalStmtBlock(ctx, scope, block)
+}
+
 func evalBranchStmt(ctx *Context, scope Scope, node *syntax.BranchStmt) *error.Error {
 	// #1. condition header
 	ifExpr, err := evalExpression(ctx, scope, node.IfTrueExpr)
@@ -435,7 +572,7 @@ func evalBranchStmt(ctx *Context, scope Scope, node *syntax.BranchStmt) *error.E
 	}
 	// exec if-branch
 	if vIfExpr.Value == true {
-		return evalStmtBlock(ctx, scope, node.IfTrueBlock)
+		return evalBranchBlock(ctx, scope, node.IfTrueBlock)
 	}
 	// exec else-if branches
 	for idx, otherExpr := range node.OtherExprs {
@@ -449,12 +586,12 @@ func evalBranchStmt(ctx *Context, scope Scope, node *syntax.BranchStmt) *error.E
 		}
 		// exec else-if branch
 		if vOtherExprI.Value == true {
-			return evalStmtBlock(ctx, scope, node.OtherBlocks[idx])
+			return evalBranchBlock(ctx, scope, node.OtherBlocks[idx])
 		}
 	}
 	// exec else branch if possible
 	if node.HasElse == true {
-		return evalStmtBlock(ctx, scope, node.IfFalseBlock)
+		return evalBranchBlock(ctx, scope, node.IfFalseBlock)
 	}
 	return nil
 }
@@ -477,6 +614,7 @@ func evalIterateStmt(ctx *Context, scope Scope, node *syntax.IterateStmt) *error
 	execIterationBlockFn := func(key ZnValue, val ZnValue) *error.Error {
 		// set values of 此之值 and 此之
 		iterScope.setCurrentKV(key, val)
+		iterScope.resetSymbols(keySlot, valueSlot)
 
 		// set pre-defined value
 		if nameLen == 1 {
@@ -554,10 +692,238 @@ func evalIterateStmt(ctx *Context, scope Scope, node *syntax.IterateStmt) *error
 	return nil
 }
 
+// evalTryStmt - 尝试 ... 捕获 ... 最终 ...
+//
+// All errors yield from TryBlock could be caught EXCEPT break signals (i.e. 返回, 此之（结束）, etc.),
+// which are not "real" errors and should be passed through untouched.
+// FinallyBlock is always executed (unless the execution is aborted by limit errors),
+// and its error (if any) overrides the previous one.
+func evalTryStmt(ctx *Context, scope Scope, node *syntax.TryStmt) *error.Error {
+	err := evalBranchBlock(ctx, scope, node.TryBlock)
+	if err != nil && node.CatchBlock != nil && isCatchableError(err) {
+		err = evalCatchBlock(ctx, scope, node, err)
+	}
+	if err != nil && err.GetErrorClass() == error.LimitErrorClass {
+		return err
+	}
+
+	if node.FinallyBlock != nil {
+		if errF := evalBranchBlock(ctx, scope, node.FinallyBlock); errF != nil {
+			return errF
+		}
+	}
+	return err
+}
+
+// evalCatchBlock - bind the caught error (as an exception object) to CatchID and
+// then execute CatchBlock
+func evalCatchBlock(ctx *Context, scope Scope, node *syntax.TryStmt, caughtErr *error.Error) *error.Error {
+	// add line info before the error is exposed to user code
+	wrapError(ctx, scope.GetRoot(), caughtErr)
+
+	if node.CatchID != nil {
+		name := node.CatchID.GetLiteral()
+		if _, inGlobals := ctx.globals[name]; inGlobals {
+			return error.NameRedeclared(name)
+		}
+		// unlike bindValue(), the exception variable could be re-bound on the same scope
+		// (e.g. a try statement inside a while loop)
+		if sym, ok := scope.GetSymbol(name); ok && sym.IsConstant {
+			return error.AssignToConstant()
+		}
+		scope.SetSymbol(name, NewZnException(caughtErr), false)
+	}
+	return evalBranchBlock(ctx, scope, node.CatchBlock)
+}
+
+// evalMatchStmt - 对于 X ： 为 ... 大于 ... 属于 ... 否则 ...
+//
+// Arms are tested in order, and only the block of the first matched arm is executed.
+// If no arm matches, ElseBlock is executed; or an error is raised if there's no ElseBlock.
+func evalMatchStmt(ctx *Context, scope Scope, node *syntax.MatchStmt) *error.Error {
+	target, err := evalExpression(ctx, scope, node.TargetExpr)
+	if err != nil {
+		return err
+	}
+	for _, arm := range node.Arms {
+		var bindings map[string]ZnValue
+		var matched bool
+		switch arm.Type {
+		case syntax.MatchArmValue:
+			// each pattern has its own bindings, thus variables bound by a failed
+			// pattern won't leak
+			for _, pattern := range arm.Values {
+				bindings = map[string]ZnValue{}
+				if matched, err = matchPattern(ctx, scope, target, pattern, bindings); err != nil || matched {
+					break
+				}
+			}
+		case syntax.MatchArmCompare:
+			matched, err = matchComparators(ctx, scope, target, arm.Comparators)
+		case syntax.MatchArmType:
+			matched, err = matchClassType(ctx, scope, target, arm.ClassName.GetLiteral())
+		}
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+		// bind matched values of hashmap shape to variables
+		for name, value := range bindings {
+			if _, inGlobals := ctx.globals[name]; inGlobals {
+				return error.NameRedeclared(name)
+			}
+			if sym, ok := scope.GetSymbol(name); ok && sym.IsConstant {
+				return error.AssignToConstant()
+			}
+			scope.SetSymbol(name, value, false)
+		}
+		return evalBranchBlock(ctx, scope, arm.Block)
+	}
+
+	if node.ElseBlock != nil {
+		return evalBranchBlock(ctx, scope, node.ElseBlock)
+	}
+	// report the error on the line of 对于 rather than the last arm
+	ctx.setCurrentLine(scope, node.GetCurrentLine())
+	return error.NoMatchedArm(target.String())
+}
+
+// matchPattern - if the value matches the pattern of 为 arm.
+//
+// A hashmap literal is treated as a shape: the value must be a hashmap that contains all keys of the
+// pattern, and an identifier in the pattern's value binds the corresponding item to the variable
+// (e.g. 为【「姓名」== 名】); other patterns are evaluated and compared with the value directly.
+func matchPattern(ctx *Context, scope Scope, value ZnValue, pattern syntax.Expression, bindings map[string]ZnValue) (bool, *error.Error) {
+	shape, ok := pattern.(*syntax.HashMapExpr)
+	if !ok {
+		expected, err := evalExpression(ctx, scope, pattern)
+		if err != nil {
+			return false, err
+		}
+		return compareValues(value, expected, CmpEq)
+	}
+
+	hashMap, ok := value.(*ZnHashMap)
+	if !ok {
+		return false, nil
+	}
+	for _, item := range shape.KVPair {
+		keyVal, err := evalExpression(ctx, scope, item.Key)
+		if err != nil {
+			return false, err
+		}
+		key, ok := keyVal.(*ZnString)
+		if !ok {
+			return false, error.InvalidExprType("string")
+		}
+		itemVal, ok := hashMap.Value[key.Value]
+		if !ok {
+			return false, nil
+		}
+		if id, ok := item.Value.(*syntax.ID); ok {
+			bindings[id.GetLiteral()] = itemVal
+			continue
+		}
+		matched, err := matchPattern(ctx, scope, itemVal, item.Value, bindings)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchComparators - if the value satisfies all comparators, e.g. 不小于60且小于90
+func matchComparators(ctx *Context, scope Scope, value ZnValue, comparators []*syntax.LogicExpr) (bool, *error.Error) {
+	for _, cmp := range comparators {
+		right, err := evalExpression(ctx, scope, cmp.RightExpr)
+		if err != nil {
+			return false, err
+		}
+		matched, err := compareByLogicType(value, right, cmp.Type)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchClassType - if the value is an instance of the class (or its child classes), or the value's type
+// is the given builtin type (e.g. 文本，数值)
+func matchClassType(ctx *Context, scope Scope, value ZnValue, name string) (bool, *error.Error) {
+	if GetTypeName(value) == name {
+		return true, nil
+	}
+	ref, err := getClassRef(ctx, scope.GetRoot(), name)
+	if err != nil {
+		if builtinTypeNames[name] {
+			return false, nil
+		}
+		return false, err
+	}
+	obj, ok := value.(*ZnObject)
+	if !ok {
+		return false, nil
+	}
+	for cr := obj.ClassRef; cr != nil; cr = cr.Parent {
+		if cr == ref {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// builtinTypeNames - all type names of builtin values (see GetTypeName)
+var builtinTypeNames = map[string]bool{
+	"文本": true, "数值": true, "二象": true, "元组": true, "列表": true, "空": true, "方法": true,
+	"异常": true, "模块": true, "日期": true, "时刻": true, "时长": true, "金额": true, "分数": true,
+}
+
+// evalThrowStmt - 抛出 「文本」 or 抛出 <exception>
+func evalThrowStmt(ctx *Context, scope Scope, node *syntax.ThrowStmt) *error.Error {
+	val, err := evalExpression(ctx, scope, node.ThrowExpr)
+	if err != nil {
+		return err
+	}
+	switch v := val.(type) {
+	case *ZnException:
+		// re-throw the original error
+		return v.Err
+	case *ZnString:
+		return error.ThrowException(v.Value)
+	default:
+		return error.ThrowInvalidValue(val.String())
+	}
+}
+
+// isCatchableError - if an error could be caught by 捕获 block
+// NOTICE: limit errors are not catchable, otherwise the script could escape from the limits.
+func isCatchableError(err *error.Error) bool {
+	errClass := err.GetErrorClass()
+	return errClass != error.BreakErrorClass && errClass != error.LimitErrorClass
+}
+
+// evalImportStmt - import a module and bind it to current scope with its namespace.
+// if namespace is not given, the file name (without extension) will be used instead.
+func evalImportStmt(ctx *Context, scope Scope, node *syntax.ImportStmt) *error.Error {
+	path := resolveModulePath(scope.GetRoot().file, node.ImportPath.GetLiteral())
+	module, err := ctx.importModule(path)
+	if err != nil {
+		return err
+	}
+
+	name := module.Name
+	if node.Namespace != nil {
+		name = node.Namespace.GetLiteral()
+	}
+	return bindValue(ctx, scope, name, module, true)
+}
+
 //// execute expressions
 
 func evalExpression(ctx *Context, scope Scope, expr syntax.Expression) (ZnValue, *error.Error) {
-	scope.GetRoot().SetCurrentLine(expr.GetCurrentLine())
+	ctx.setCurrentLine(scope, expr.GetCurrentLine())
 	switch e := expr.(type) {
 	case *syntax.VarAssignExpr:
 		return evalVarAssignExpr(ctx, scope, e)
@@ -566,6 +932,8 @@ func evalExpression(ctx *Context, scope Scope, expr syntax.Expression) (ZnValue,
 			return evalLogicCombiner(ctx, scope, e)
 		}
 		return evalLogicComparator(ctx, scope, e)
+	case *syntax.ArithExpr:
+		return evalArithExpr(ctx, scope, e)
 	case *syntax.MemberExpr:
 		iv, err := getMemberExprIV(ctx, scope, e)
 		if err != nil {
@@ -585,13 +953,14 @@ func evalExpression(ctx *Context, scope Scope, expr syntax.Expression) (ZnValue,
 func evalFunctionCall(ctx *Context, scope Scope, expr *syntax.FuncCallExpr) (ZnValue, *error.Error) {
 	vtag := expr.FuncName.GetLiteral()
 	var zf *ClosureRef
+	var targetThis ZnValue
 
 	// if current scope is FuncScope, find ID from funcScope's "targetThis" method list
 	if sp, ok := scope.(*FuncScope); ok {
-		targetThis := sp.GetTargetThis()
-		if targetThis != nil {
-			if val, err := targetThis.GetMethod(vtag); err == nil {
+		if this := sp.GetTargetThis(); this != nil {
+			if val, err := this.GetMethod(vtag); err == nil {
 				zf = val
+				targetThis = this
 			}
 		}
 	}
@@ -617,11 +986,183 @@ func evalFunctionCall(ctx *Context, scope Scope, expr *syntax.FuncCallExpr) (ZnV
 		return nil, err
 	}
 
-	fScope := NewFuncScope(scope, nil)
+	// the new FuncScope derives from the function's defining scope (if any)
+	fScope := zf.newFuncScope(scope, targetThis)
 	// exec function call via its ClosureRef
 	return zf.Exec(ctx, fScope, params)
 }
 
+// evalArithExpr - evaluate arithmetic expressions
+// such as A ＋ B，A × B，－A
+// NOTICE: only decimals, money (see evalMoneyArith) and fractions (see evalFractionArith) are valid operands
+func evalArithExpr(ctx *Context, scope Scope, expr *syntax.ArithExpr) (ZnValue, *error.Error) {
+	evalOperand := func(e syntax.Expression) (ZnValue, *error.Error) {
+		val, err := evalExpression(ctx, scope, e)
+		if err != nil {
+			return nil, err
+		}
+		switch val.(type) {
+		case *ZnDecimal, *ZnMoney, *ZnFraction:
+			return val, nil
+		}
+		return nil, error.InvalidExprType("decimal")
+	}
+	// for negative expr, there's only right operand
+	arith := getArith(ctx, scope)
+	if expr.Type == syntax.ArithNEG {
+		right, err := evalOperand(expr.RightExpr)
+		if err != nil {
+			return nil, err
+		}
+		switch v := right.(type) {
+		case *ZnMoney:
+			return NewZnMoney(arith.Neg(v.Amount), v.Currency), nil
+		case *ZnFraction:
+			return NewZnFraction(new(big.Rat).Neg(v.Value)), nil
+		}
+		return arith.Neg(right.(*ZnDecimal)), nil
+	}
+
+	// #1. eval left
+	leftValue, err := evalOperand(expr.LeftExpr)
+	if err != nil {
+		return nil, err
+	}
+	// #2. eval right
+	rightValue, err := evalOperand(expr.RightExpr)
+	if err != nil {
+		return nil, err
+	}
+	left, lok := leftValue.(*ZnDecimal)
+	right, rok := rightValue.(*ZnDecimal)
+	if !lok || !rok {
+		_, lf := leftValue.(*ZnFraction)
+		_, rf := rightValue.(*ZnFraction)
+		if lf || rf {
+			return evalFractionArith(expr.Type, leftValue, rightValue)
+		}
+		return evalMoneyArith(arith, expr.Type, leftValue, rightValue)
+	}
+	// #3. do calculation
+	switch expr.Type {
+	case syntax.ArithADD:
+		return arith.Add(left, right), nil
+	case syntax.ArithSUB:
+		return arith.Sub(left, right), nil
+	case syntax.ArithMUL:
+		return arith.Mul(left, right), nil
+	case syntax.ArithDIV:
+		return arith.Div(left, right)
+	case syntax.ArithMOD:
+		return arith.Mod(left, right)
+	}
+	return nil, error.UnExpectedCase("运算类型", strconv.Itoa(int(expr.Type)))
+}
+
+// evalMoneyArith - arithmetic of money:
+//
+// money ＋ money, money － money: same currency only
+// money × decimal, decimal × money, money ÷ decimal: returns money
+// money ÷ money: same currency only, returns the ratio as a decimal
+// －money: returns money
+//
+// no rounding is applied except division, i.e. the results are exact amounts.
+func evalMoneyArith(arith *Arith, arithType syntax.ArithTypeE, left ZnValue, right ZnValue) (ZnValue, *error.Error) {
+	lm, lok := left.(*ZnMoney)
+	rm, rok := right.(*ZnMoney)
+	ld, _ := left.(*ZnDecimal)
+	rd, _ := right.(*ZnDecimal)
+	// money with money - currencies should be same
+	if lok && rok && lm.Currency != rm.Currency {
+		return nil, error.CurrencyMismatch(lm.Currency.Code, rm.Currency.Code)
+	}
+
+	switch arithType {
+	case syntax.ArithADD, syntax.ArithSUB:
+		if !lok || !rok {
+			return nil, error.InvalidExprType("money")
+		}
+		if arithType == syntax.ArithADD {
+			return NewZnMoney(arith.Add(lm.Amount, rm.Amount), lm.Currency), nil
+		}
+		return NewZnMoney(arith.Sub(lm.Amount, rm.Amount), lm.Currency), nil
+	case syntax.ArithMUL:
+		if lok && rok {
+			return nil, error.InvalidExprType("decimal")
+		}
+		if lok {
+			return NewZnMoney(arith.Mul(lm.Amount, rd), lm.Currency), nil
+		}
+		return NewZnMoney(arith.Mul(ld, rm.Amount), rm.Currency), nil
+	case syntax.ArithDIV:
+		if !lok {
+			return nil, error.InvalidExprType("decimal")
+		}
+		if rok {
+			return arith.Div(lm.Amount, rm.Amount)
+		}
+		// divide exactly and keep (precision) places more than minor units, so that large
+		// amounts are not cut to significant digits, e.g. ¥123456789 ÷ 7 = ¥17636684.1428571429
+		r, err := quoDecimals(lm.Amount, rd)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := roundRat(r, lm.Currency.MinorUnits+arith.precision, arith.rounding)
+		if err != nil {
+			return nil, err
+		}
+		// e.g. ¥12.50 ÷ 4 = ¥3.125 instead of ¥3.1250000000
+		trimTailZeros(amount)
+		return NewZnMoney(amount, lm.Currency), nil
+	}
+	return nil, error.InvalidExprType("decimal")
+}
+
+// evalFractionArith - arithmetic of fractions (and decimals), the results are always
+// exact fractions, e.g. 1/3 × 3 = 1, 1/3 ＋ 0.5 = 5/6
+func evalFractionArith(arithType syntax.ArithTypeE, left ZnValue, right ZnValue) (ZnValue, *error.Error) {
+	toRat := func(val ZnValue) (*big.Rat, *error.Error) {
+		switch v := val.(type) {
+		case *ZnFraction:
+			return v.Value, nil
+		case *ZnDecimal:
+			return ratFromDecimal(v)
+		}
+		return nil, error.InvalidExprType("decimal", "fraction")
+	}
+	a, err := toRat(left)
+	if err != nil {
+		return nil, err
+	}
+	b, err := toRat(right)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(big.Rat)
+	switch arithType {
+	case syntax.ArithADD:
+		result.Add(a, b)
+	case syntax.ArithSUB:
+		result.Sub(a, b)
+	case syntax.ArithMUL:
+		result.Mul(a, b)
+	case syntax.ArithDIV, syntax.ArithMOD:
+		if b.Sign() == 0 {
+			return nil, error.ArithDivZeroError()
+		}
+		result.Quo(a, b)
+		// A % B = A - B × trunc(A / B), same as decimals
+		if arithType == syntax.ArithMOD {
+			quo := new(big.Int).Quo(result.Num(), result.Denom())
+			result.Sub(a, new(big.Rat).Mul(b, new(big.Rat).SetInt(quo)))
+		}
+	default:
+		return nil, error.UnExpectedCase("运算类型", strconv.Itoa(int(arithType)))
+	}
+	return NewZnFraction(result), nil
+}
+
 // evaluate logic combination expressions
 // such as A 且 B
 // or A 或 B
@@ -682,9 +1223,15 @@ func evalLogicComparator(ctx *Context, scope Scope, expr *syntax.LogicExpr) (*Zn
 		return nil, err
 	}
 
+	// #3. do comparison
+	cmpRes, cmpErr := compareByLogicType(left, right, logicType)
+	return NewZnBool(cmpRes), cmpErr
+}
+
+// compareByLogicType - compare two values by the comparator of logic expression (e.g. 等于，不小于)
+func compareByLogicType(left ZnValue, right ZnValue, logicType syntax.LogicTypeE) (bool, *error.Error) {
 	var cmpRes bool
 	var cmpErr *error.Error
-	// #3. do comparison
 	switch logicType {
 	case syntax.LogicEQ:
 		cmpRes, cmpErr = compareValues(left, right, CmpEq)
@@ -697,7 +1244,7 @@ func evalLogicComparator(ctx *Context, scope Scope, expr *syntax.LogicExpr) (*Zn
 		var cmp1, cmp2 bool
 		cmp1, cmpErr = compareValues(left, right, CmpGt)
 		if cmpErr != nil {
-			return nil, cmpErr
+			return false, cmpErr
 		}
 		cmp2, cmpErr = compareValues(left, right, CmpEq)
 		cmpRes = cmp1 || cmp2
@@ -707,21 +1254,25 @@ func evalLogicComparator(ctx *Context, scope Scope, expr *syntax.LogicExpr) (*Zn
 		var cmp1, cmp2 bool
 		cmp1, cmpErr = compareValues(left, right, CmpLt)
 		if cmpErr != nil {
-			return nil, cmpErr
+			return false, cmpErr
 		}
 		cmp2, cmpErr = compareValues(left, right, CmpEq)
 		cmpRes = cmp1 || cmp2
 	default:
-		return nil, error.UnExpectedCase("比较类型", strconv.Itoa(int(logicType)))
+		return false, error.UnExpectedCase("比较类型", strconv.Itoa(int(logicType)))
 	}
 
-	return NewZnBool(cmpRes), cmpErr
+	return cmpRes, cmpErr
 }
 
 // eval prime expr
 func evalPrimeExpr(ctx *Context, scope Scope, expr syntax.Expression) (ZnValue, *error.Error) {
 	switch e := expr.(type) {
 	case *syntax.Number:
+		// money literals, e.g. ¥12.50，三千五百元
+		if money, isMoney, err := newMoneyFromLiteral(e.GetLiteral()); isMoney {
+			return money, err
+		}
 		return NewZnDecimal(e.GetLiteral())
 	case *syntax.String:
 		return NewZnString(e.GetLiteral()), nil
@@ -818,6 +1369,10 @@ func getMemberExprIV(ctx *Context, scope Scope, expr *syntax.MemberExpr) (ZnIV,
 	}
 
 	// RootType = RootTypeExpr
+	// e.g. 父类之（方法） - calls the implementation of parent class
+	if iv, ok, err := getParentMemberIV(ctx, scope, expr); ok {
+		return iv, err
+	}
 	valRoot, err := evalExpression(ctx, scope, expr.Root)
 	if err != nil {
 		return nil, err
@@ -848,29 +1403,67 @@ func getMemberExprIV(ctx *Context, scope Scope, expr *syntax.MemberExpr) (ZnIV,
 			}
 			return &ZnArrayIV{v, vr}, nil
 		case *ZnHashMap:
-			var s *ZnString
-			switch x := idx.(type) {
-			// regard decimal value directly as string
-			case *ZnDecimal:
-				// transform decimal value to string
-				// x.exp < 0 express that its a decimal value with point mark, not an integer
-				if x.exp < 0 {
-					return nil, error.InvalidExprType("integer", "string")
-				}
-				s = NewZnString(x.String())
-			case *ZnString:
-				s = x
-			default:
+			key, ok := getHashMapKey(idx)
+			if !ok {
 				return nil, error.InvalidExprType("integer", "string")
 			}
-			return &ZnHashMapIV{v, s}, nil
+			return &ZnHashMapIV{v, NewZnString(key)}, nil
+		case *ZnString:
+			vr, ok := idx.(*ZnDecimal)
+			if !ok {
+				return nil, error.InvalidExprType("integer")
+			}
+			return &ZnStringIV{v, vr}, nil
 		default:
-			return nil, error.InvalidExprType("array", "hashmap")
+			return nil, error.InvalidExprType("array", "hashmap", "string")
 		}
 	}
 	return nil, error.UnExpectedCase("子项类型", reflect.TypeOf(expr.MemberType).Name())
 }
 
+// getParentMemberIV - inside a method of child class, `父类名之（方法）` or `父类名之属性`
+// accesses the implementation of its parent class on current object (targetThis).
+// NOTICE: a defined variable with same name as the parent class takes higher priority.
+//
+// returns (iv, matched, error)
+func getParentMemberIV(ctx *Context, scope Scope, expr *syntax.MemberExpr) (ZnIV, bool, *error.Error) {
+	rootID, ok := expr.Root.(*syntax.ID)
+	if !ok {
+		return nil, false, nil
+	}
+	name := rootID.GetLiteral()
+	if _, err := getValue(ctx, scope, name); err == nil {
+		return nil, false, nil
+	}
+	// find current object from the nearest FuncScope
+	var this *ZnObject
+	for sp := scope; sp != nil; sp = sp.GetParent() {
+		if fs, ok := sp.(*FuncScope); ok {
+			this, _ = fs.GetTargetThis().(*ZnObject)
+			break
+		}
+	}
+	if this == nil {
+		return nil, false, nil
+	}
+	parentRef, ok := this.ClassRef.findParent(name)
+	if !ok {
+		return nil, false, nil
+	}
+
+	switch expr.MemberType {
+	case syntax.MemberID:
+		return &ZnParentIV{this, parentRef, expr.MemberID.Literal, nil, false}, true, nil
+	case syntax.MemberMethod:
+		paramVals, err := exprsToValues(ctx, scope, expr.MemberMethod.Params)
+		if err != nil {
+			return nil, true, err
+		}
+		return &ZnParentIV{this, parentRef, expr.MemberMethod.FuncName.Literal, paramVals, true}, true, nil
+	}
+	return nil, false, nil
+}
+
 //// scope value setters/getters
 func getValue(ctx *Context, scope Scope, name string) (ZnValue, *error.Error) {
 	// find on globals first
@@ -916,16 +1509,42 @@ func getClassRef(ctx *Context, scope *RootScope, name string) (*ClassRef, *error
 	if ok {
 		return ref, nil
 	}
+	// ...then find in native classes
+	if ref, ok := ctx.classRefs[name]; ok {
+		return ref, nil
+	}
 	return nil, error.NameNotDefined(name)
 }
 
+func getModule(ctx *Context, scope Scope, name string) (*ZnModule, *error.Error) {
+	val, err := getValue(ctx, scope, name)
+	if err != nil {
+		return nil, err
+	}
+	module, ok := val.(*ZnModule)
+	if !ok {
+		return nil, error.InvalidExprType("module")
+	}
+	return module, nil
+}
+
 func bindClassRef(ctx *Context, scope *RootScope, classStmt *syntax.ClassDeclareStmt) *error.Error {
 	name := classStmt.ClassName.GetLiteral()
 	_, ok := scope.classRefMap[name]
-	if ok {
+	_, isNative := ctx.classRefs[name]
+	if ok || isNative {
 		return error.NameRedeclared(name)
 	}
-	scope.classRefMap[name] = NewClassRef(name, classStmt)
+	// parent class should be defined before
+	var parentRef *ClassRef
+	if classStmt.ParentClass != nil {
+		ref, err := getClassRef(ctx, scope, classStmt.ParentClass.GetLiteral())
+		if err != nil {
+			return err
+		}
+		parentRef = ref
+	}
+	scope.classRefMap[name] = NewClassRef(name, classStmt, parentRef, scope)
 	return nil
 }
 
@@ -943,10 +1562,55 @@ func bindValue(ctx *Context, scope Scope, name string, value ZnValue, isConstatn
 
 //// helpers
 
+// callFunction - call a function value (e.g. a callback passed to native methods)
+func callFunction(ctx *Context, scope Scope, fn *ZnFunction, params []ZnValue) (ZnValue, *error.Error) {
+	fScope := fn.newFuncScope(scope, nil)
+	return fn.Exec(ctx, fScope, params)
+}
+
+// getHashMapKey - get key of a hashmap from a string or an integer value
+func getHashMapKey(val ZnValue) (string, bool) {
+	switch x := val.(type) {
+	// regard decimal value directly as string
+	case *ZnDecimal:
+		// transform decimal value to string
+		// x.exp < 0 express that its a decimal value with point mark, not an integer
+		if x.exp < 0 {
+			return "", false
+		}
+		return x.String(), true
+	case *ZnString:
+		return x.Value, true
+	}
+	return "", false
+}
+
 // exprsToValues - []syntax.Expression -> []eval.ZnValue
+// items of an array are expanded as values for spread expressions (e.g. 列表……),
+// and named params (e.g. 税率 == 0.13) are wrapped as ZnNamedParam
 func exprsToValues(ctx *Context, scope Scope, exprs []syntax.Expression) ([]ZnValue, *error.Error) {
 	params := []ZnValue{}
 	for _, paramExpr := range exprs {
+		if spread, ok := paramExpr.(*syntax.SpreadExpr); ok {
+			pval, err := evalExpression(ctx, scope, spread.Expr)
+			if err != nil {
+				return nil, err
+			}
+			arr, ok := pval.(*ZnArray)
+			if !ok {
+				return nil, error.InvalidParamType("array")
+			}
+			params = append(params, arr.Value...)
+			continue
+		}
+		if named, ok := paramExpr.(*syntax.NamedParamExpr); ok {
+			pval, err := evalExpression(ctx, scope, named.Value)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, NewZnNamedParam(named.Name.GetLiteral(), pval))
+			continue
+		}
 		pval, err := evalExpression(ctx, scope, paramExpr)
 		if err != nil {
 			return nil, err
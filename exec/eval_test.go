@@ -423,12 +423,1301 @@ func Test_WhileLoopStmt(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "declare variables in loop body",
+			program: `
+每当X大于0：
+	令Z为（X+Y：X，10）
+	（__probe：「$Z」，Z）
+	X为（X-Y：X，1）`,
+			symbols: map[string]ZnValue{
+				"X": NewZnDecimalFromInt(2, 0),
+			},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$Z": {
+					{"12", "*exec.ZnDecimal"},
+					{"11", "*exec.ZnDecimal"},
+				},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_TryStmt(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "catch runtime error",
+			program: `
+尝试：
+	（__probe：「$T1」，1）
+	（X+Y：某变量，1）
+	（__probe：「$T2」，2）
+捕获错误：
+	（__probe：「$CODE」，错误之代码）
+	（__probe：「$CLASS」，错误之类别）
+	（__probe：「$INFO」，错误之详情）
+最终：
+	（__probe：「$F」，3）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$T1":    {{"1", "*exec.ZnDecimal"}},
+				"$T2":    {},
+				"$CODE":  {{"「2501」", "*exec.ZnString"}},
+				"$CLASS": {{"「标识错误」", "*exec.ZnString"}},
+				"$INFO":  {{"【name == 「某变量」】", "*exec.ZnHashMap"}},
+				"$F":     {{"3", "*exec.ZnDecimal"}},
+			},
+		},
+		{
+			name: "throw custom text and catch it inside while loop",
+			program: `
+每当X大于0：
+	X为（X-Y：X，1）
+	尝试：
+		抛出「余额不足」
+	捕获E：
+		（__probe：「$E」，E之信息）`,
+			symbols: map[string]ZnValue{
+				"X": NewZnDecimalFromInt(2, 0),
+			},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$E": {
+					{"「余额不足」", "*exec.ZnString"},
+					{"「余额不足」", "*exec.ZnString"},
+				},
+			},
+		},
+		{
+			name: "break signal passes through try statement",
+			program: `
+每当X大于0：
+	尝试：
+		此之（结束）
+	捕获E：
+		（__probe：「$E」，E）
+	最终：
+		（__probe：「$F」，X）
+	X为0`,
+			symbols: map[string]ZnValue{
+				"X": NewZnDecimalFromInt(2, 0),
+			},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$E": {},
+				"$F": {{"2", "*exec.ZnDecimal"}},
+			},
+		},
+		{
+			name: "rethrow caught exception",
+			program: `
+尝试：
+	尝试：
+		（X/Y：1，0）
+	捕获E：
+		抛出E
+捕获E2：
+	（__probe：「$E2」，E2之代码）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$E2": {{"「2601」", "*exec.ZnString"}},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_FunctionClosure(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "returned inner function captures its defining scope",
+			program: `
+如何生成计数器？
+	令计数为0
+	如何计数器？
+		计数为（X+Y：计数，1）
+		返回计数
+	返回计数器
+
+令甲为（生成计数器）
+令乙为（生成计数器）
+（__probe：「$A」，（甲））
+（__probe：「$A」，（甲））
+（__probe：「$B」，（乙））`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(1, 0),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"1", "*exec.ZnDecimal"},
+					{"2", "*exec.ZnDecimal"},
+				},
+				"$B": {{"1", "*exec.ZnDecimal"}},
+			},
+		},
+		{
+			name: "function could not read caller's local variables",
+			program: `
+如何读取？
+	返回局部值
+如何调用者？
+	令局部值为1
+	返回（读取）
+
+尝试：
+	（调用者）
+捕获E：
+	（__probe：「$E」，E之代码）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$E": {{"「2501」", "*exec.ZnString"}},
+			},
+		},
+		{
+			name: "method calls another method of the same object",
+			program: `
+定义账户：
+	其余额为100
+	如何取余额？
+		返回其余额
+	如何查询？
+		返回（取余额）
+
+令甲成为账户
+（__probe：「$R」，甲之（查询））`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(100, 0),
+			expProbe: map[string][][]string{
+				"$R": {{"100", "*exec.ZnDecimal"}},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_ClassInheritance(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "inherit & override properties, getters and methods",
+			program: `
+定义订单：
+	其金额为0
+	其状态为「待支付」
+	是为金额
+
+	何为描述？
+		返回「普通订单」
+
+	如何计算总额？
+		已知运费
+		返回其金额 ＋ 运费
+
+定义预售订单继承订单：
+	其定金为10
+	其状态为「预定中」
+
+	何为描述？
+		返回「预售订单」
+
+	如何计算总额？
+		已知运费
+		返回订单之（计算总额：运费） － 其定金
+
+	如何原描述？
+		返回订单之描述
+
+定义定制订单继承预售订单：
+	其备注为「」
+
+令甲成为预售订单：100
+令乙成为定制订单：200
+（__probe：「$D」，甲之描述）
+（__probe：「$D」，甲之（原描述））
+（__probe：「$S」，甲之状态）
+（__probe：「$S」，乙之状态）
+（__probe：「$T」，甲之（计算总额：5））
+（__probe：「$T」，乙之（计算总额：5））`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(195, 0),
+			expProbe: map[string][][]string{
+				"$D": {
+					{"「预售订单」", "*exec.ZnString"},
+					{"「普通订单」", "*exec.ZnString"},
+				},
+				"$S": {
+					{"「预定中」", "*exec.ZnString"},
+					{"「预定中」", "*exec.ZnString"},
+				},
+				"$T": {
+					{"95", "*exec.ZnDecimal"},
+					{"195", "*exec.ZnDecimal"},
+				},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_ArithExpr(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "operator precedence",
+			program: `
+（__probe：「$A」，1 + 2 * 3）
+（__probe：「$A」，{1 + 2} × 3）
+（__probe：「$A」，10 － 4 － 3）
+（__probe：「$A」，7 ％ 3 ＋ 2）
+（__probe：「$A」，1.5 * 2 -1）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: newDecimal("2.0"),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"7", "*exec.ZnDecimal"},
+					{"9", "*exec.ZnDecimal"},
+					{"3", "*exec.ZnDecimal"},
+					{"3", "*exec.ZnDecimal"},
+					{"2.0", "*exec.ZnDecimal"},
+				},
+			},
+		},
+		{
+			name: "negative values & division",
+			program: `
+（__probe：「$A」，－A × 2）
+（__probe：「$A」，- A ÷ -4）
+（__probe：「$A」，-7.5 % 2）
+（__probe：「$B」，A）`,
+			symbols: map[string]ZnValue{
+				"A": NewZnDecimalFromInt(5, 0),
+			},
+			expReturnValue: NewZnDecimalFromInt(5, 0),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"-10", "*exec.ZnDecimal"},
+					{"1.2500000", "*exec.ZnDecimal"},
+					{"-1.5", "*exec.ZnDecimal"},
+				},
+				"$B": {{"5", "*exec.ZnDecimal"}},
+			},
+		},
+		{
+			name: "arith errors could be caught",
+			program: `
+尝试：
+	A ％ 0
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	A ＋ 「1」
+捕获E：
+	（__probe：「$E」，E之代码）`,
+			symbols: map[string]ZnValue{
+				"A": NewZnDecimalFromInt(5, 0),
+			},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$E": {
+					{"「2601」", "*exec.ZnString"},
+					{"「2301」", "*exec.ZnString"},
+				},
+			},
+		},
 	}
 	for _, tt := range suites {
 		assertSuite(t, tt)
 	}
 }
 
+func Test_ArrayMethods(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "modify items",
+			program: `
+令数组为【3，1，2】
+数组之（添加：5，4）
+（__probe：「$A」，数组）
+（__probe：「$B」，数组之（弹出））
+数组之（插入：0，9）
+（__probe：「$A」，数组）
+（__probe：「$B」，数组之（删除：1））
+（__probe：「$A」，数组之（反转））
+（__probe：「$B」，【】之（弹出））
+数组之数目`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(4, 0),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"【3，1，2，5，4】", "*exec.ZnArray"},
+					{"【9，3，1，2，5】", "*exec.ZnArray"},
+					{"【5，2，1，9】", "*exec.ZnArray"},
+				},
+				"$B": {
+					{"4", "*exec.ZnDecimal"},
+					{"3", "*exec.ZnDecimal"},
+					{"空", "*exec.ZnNull"},
+				},
+			},
+		},
+		{
+			name: "query items",
+			program: `
+令数组为【「甲」，「乙」，「丙」，「丁」】
+（__probe：「$A」，数组之（切片：1，3））
+（__probe：「$A」，数组之（切片：2））
+（__probe：「$B」，数组之（查找：「丙」））
+（__probe：「$B」，数组之（查找：「戊」））
+（__probe：「$C」，数组之（包含：「乙」））
+（__probe：「$C」，数组之（包含：1））
+（__probe：「$C」，【【1，2】，【1，3】】之（包含：【1，3】））
+数组之（连接：「、」）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnString("甲、乙、丙、丁"),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"【「乙」，「丙」】", "*exec.ZnArray"},
+					{"【「丙」，「丁」】", "*exec.ZnArray"},
+				},
+				"$B": {
+					{"2", "*exec.ZnDecimal"},
+					{"-1", "*exec.ZnDecimal"},
+				},
+				"$C": {
+					{"真", "*exec.ZnBool"},
+					{"假", "*exec.ZnBool"},
+					{"真", "*exec.ZnBool"},
+				},
+			},
+		},
+		{
+			name: "sort, map, filter & reduce",
+			program: `
+如何降序？
+	已知甲，乙
+	返回甲大于乙
+
+如何按长度？
+	已知甲，乙
+	返回甲之数目 - 乙之数目
+
+如何加倍？
+	已知数
+	返回数 * 2
+
+如何取偶数？
+	已知数
+	返回数 % 2 等于 0
+
+如何相加？
+	已知甲，乙
+	返回甲 + 乙
+
+令数组为【3，1，4，1，5，9，2，6】
+（__probe：「$A」，数组之（排序））
+（__probe：「$A」，数组之（排序：降序））
+（__probe：「$A」，【【1，2，3】，【1】，【1，2】】之（排序：按长度））
+（__probe：「$A」，【「乙」，「丙」，「甲」】之（排序））
+（__probe：「$A」，数组之（映射：加倍））
+（__probe：「$A」，数组之（过滤：取偶数））
+数组之（归约：相加，0）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(31, 0),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"【1，1，2，3，4，5，6，9】", "*exec.ZnArray"},
+					{"【9，6，5，4，3，2，1，1】", "*exec.ZnArray"},
+					{"【【1】，【1，2】，【1，2，3】】", "*exec.ZnArray"},
+					{"【「丙」，「乙」，「甲」】", "*exec.ZnArray"},
+					{"【18，12，10，8，6，4，2，2】", "*exec.ZnArray"},
+					{"【6，4，2】", "*exec.ZnArray"},
+				},
+			},
+		},
+		{
+			name: "method errors could be caught",
+			program: `
+令数组为【1，2】
+尝试：
+	数组之（删除：2）
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	数组之（映射：3）
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	【1，「2」】之（排序）
+捕获E：
+	（__probe：「$E」，E之代码）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$E": {
+					{"「2401」", "*exec.ZnString"},
+					{"「2303」", "*exec.ZnString"},
+					{"「2301」", "*exec.ZnString"},
+				},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_CompareCollections(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "all items of arrays & hashmaps are compared",
+			program: `
+（__probe：「$A」，【1，2】等于【1，2】）
+（__probe：「$A」，【1，2】等于【1，3】）
+（__probe：「$A」，【「甲」== 1，「乙」== 2】等于【「甲」== 1，「乙」== 2】）
+（__probe：「$A」，【「甲」== 1，「乙」== 2】等于【「甲」== 1，「乙」== 3】）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnBool(false),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"真", "*exec.ZnBool"},
+					{"假", "*exec.ZnBool"},
+					{"真", "*exec.ZnBool"},
+					{"假", "*exec.ZnBool"},
+				},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_StringMethods(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "getters & index",
+			program: `
+令文为「中华人民共和国」
+（__probe：「$A」，文之长度）
+（__probe：「$B」，文之首字）
+（__probe：「$B」，文之尾字）
+（__probe：「$B」，文#2）
+（__probe：「$B」，「」之首字）
+「」之长度`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(0, 0),
+			expProbe: map[string][][]string{
+				"$A": {{"7", "*exec.ZnDecimal"}},
+				"$B": {
+					{"「中」", "*exec.ZnString"},
+					{"「国」", "*exec.ZnString"},
+					{"「人」", "*exec.ZnString"},
+					{"「」", "*exec.ZnString"},
+				},
+			},
+		},
+		{
+			name: "string methods",
+			program: `
+令文为「中华人民共和国」
+（__probe：「$A」，「总计：」之（拼接：100，「元」））
+（__probe：「$A」，文之（截取：2，4））
+（__probe：「$A」，文之（截取：4））
+（__probe：「$A」，文之（替换：「人民」，「Zn」））
+（__probe：「$A」，「　 Zn语言 　」之（去空白））
+（__probe：「$A」，「Zn」之（大写））
+（__probe：「$A」，「Zn」之（小写））
+（__probe：「$A」，「哈」之（重复：3））
+（__probe：「$B」，文之（查找：「共和」））
+（__probe：「$B」，文之（查找：「Zn」））
+（__probe：「$C」，文之（包含：「人民」））
+（__probe：「$C」，文之（开头是：「中华」））
+（__probe：「$C」，文之（结尾是：「中华」））
+（__probe：「$D」，「甲、乙、丙」之（分割：「、」））
+（__probe：「$D」，「中文」之（分割：「」））
+文之（分割：「人民」）之数目`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(2, 0),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"「总计：100元」", "*exec.ZnString"},
+					{"「人民」", "*exec.ZnString"},
+					{"「共和国」", "*exec.ZnString"},
+					{"「中华Zn共和国」", "*exec.ZnString"},
+					{"「Zn语言」", "*exec.ZnString"},
+					{"「ZN」", "*exec.ZnString"},
+					{"「zn」", "*exec.ZnString"},
+					{"「哈哈哈」", "*exec.ZnString"},
+				},
+				"$B": {
+					{"4", "*exec.ZnDecimal"},
+					{"-1", "*exec.ZnDecimal"},
+				},
+				"$C": {
+					{"真", "*exec.ZnBool"},
+					{"真", "*exec.ZnBool"},
+					{"假", "*exec.ZnBool"},
+				},
+				"$D": {
+					{"【「甲」，「乙」，「丙」】", "*exec.ZnArray"},
+					{"【「中」，「文」】", "*exec.ZnArray"},
+				},
+			},
+		},
+		{
+			name: "string errors could be caught",
+			program: `
+令文为「中文」
+尝试：
+	文#2
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	文#0为「英」
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	文之（包含：1）
+捕获E：
+	（__probe：「$E」，E之代码）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$E": {
+					{"「2401」", "*exec.ZnString"},
+					{"「2403」", "*exec.ZnString"},
+					{"「2303」", "*exec.ZnString"},
+				},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_HashMapMethods(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "assign new keys & delete keys",
+			program: `
+令表为【「苹果」 == 5，「香蕉」 == 3】
+表#「橙子」为8
+表#「苹果」为6
+表#2021为「年」
+（__probe：「$A」，表）
+（__probe：「$B」，表之（删除：「香蕉」））
+表#「香蕉」为1
+（__probe：「$A」，表）
+（__probe：「$C」，表之（含有：「香蕉」））
+（__probe：「$C」，表之（含有：「葡萄」））
+（__probe：「$C」，表之（含有：2021））
+表之数目`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(4, 0),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"【苹果 == 6，香蕉 == 3，橙子 == 8，2021 == 「年」】", "*exec.ZnHashMap"},
+					{"【苹果 == 6，橙子 == 8，2021 == 「年」，香蕉 == 1】", "*exec.ZnHashMap"},
+				},
+				"$B": {{"3", "*exec.ZnDecimal"}},
+				"$C": {
+					{"真", "*exec.ZnBool"},
+					{"假", "*exec.ZnBool"},
+					{"真", "*exec.ZnBool"},
+				},
+			},
+		},
+		{
+			name: "keys, values, merge & get",
+			program: `
+令表为【「甲」 == 1，「乙」 == 2】
+（__probe：「$A」，表之键列表）
+（__probe：「$A」，表之（值列表））
+（__probe：「$B」，表之（合并：【「丙」 == 3，「甲」 == 10】，【「丁」 == 4】））
+（__probe：「$C」，表之（获取：「甲」，0））
+（__probe：「$C」，表之（获取：「戊」，0））
+（__probe：「$C」，表之（获取：「戊」））
+表之（数目）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(4, 0),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"【「甲」，「乙」】", "*exec.ZnArray"},
+					{"【1，2】", "*exec.ZnArray"},
+				},
+				"$B": {{"【甲 == 10，乙 == 2，丙 == 3，丁 == 4】", "*exec.ZnHashMap"}},
+				"$C": {
+					{"10", "*exec.ZnDecimal"},
+					{"0", "*exec.ZnDecimal"},
+					{"空", "*exec.ZnNull"},
+				},
+			},
+		},
+		{
+			name: "hashmap errors could be caught",
+			program: `
+令表为【「甲」 == 1】
+尝试：
+	表#「乙」
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	表之（删除：「乙」）
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	表之（合并：【1，2】）
+捕获E：
+	（__probe：「$E」，E之代码）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$E": {
+					{"「2402」", "*exec.ZnString"},
+					{"「2402」", "*exec.ZnString"},
+					{"「2303」", "*exec.ZnString"},
+				},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_DecimalMath(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "math functions & methods",
+			program: `
+令价格为12.345
+（__probe：「$R」，（四舍五入：价格，2））
+（__probe：「$R」，价格之（四舍五入：2，「四舍六入五成双」））
+（__probe：「$R」，（取整：-2.5））
+（__probe：「$R」，-2.5之（取整：「截断」））
+（__probe：「$A」，（求绝对值：-3.5））
+（__probe：「$A」，-3.5之绝对值）
+（__probe：「$A」，（求余：7，3））
+（__probe：「$A」，-7之（整除：2））
+（__probe：「$A」，（乘方：1.5，3））
+（__probe：「$A」，2之（开方））
+（__probe：「$M」，（求最大值：3，7.5，2））
+（__probe：「$M」，3之（最小值：7.5，2））
+（__probe：「$M」，（比较大小：1.0，1））
+价格之（比较：20）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(-1, 0),
+			expProbe: map[string][][]string{
+				"$R": {
+					{"12.35", "*exec.ZnDecimal"},
+					{"12.34", "*exec.ZnDecimal"},
+					{"-3", "*exec.ZnDecimal"},
+					{"-2", "*exec.ZnDecimal"},
+				},
+				"$A": {
+					{"3.5", "*exec.ZnDecimal"},
+					{"3.5", "*exec.ZnDecimal"},
+					{"1", "*exec.ZnDecimal"},
+					{"-3", "*exec.ZnDecimal"},
+					{"3.375", "*exec.ZnDecimal"},
+					{"1.4142136", "*exec.ZnDecimal"},
+				},
+				"$M": {
+					{"7.5", "*exec.ZnDecimal"},
+					{"2", "*exec.ZnDecimal"},
+					{"0", "*exec.ZnDecimal"},
+				},
+			},
+		},
+		{
+			name: "math errors could be caught",
+			program: `
+尝试：
+	（开方：-1）
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	（四舍五入：1.5，0，「随便」）
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	（乘方：2，0.5）
+捕获E：
+	（__probe：「$E」，E之代码）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$E": {
+					{"「2604」", "*exec.ZnString"},
+					{"「2605」", "*exec.ZnString"},
+					{"「2303」", "*exec.ZnString"},
+				},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_ArithSettings(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "settings are scoped",
+			program: `
+如何高精度？
+	此之（精度：20）
+	（__probe：「$S」，此之精度）
+	返回1 ÷ 3
+
+（__probe：「$A」，1 ÷ 3）
+（__probe：「$A」，（高精度））
+（__probe：「$S」，此之精度）
+以V遍历【1】：
+	此之精度为3
+	（__probe：「$A」，2 ÷ 3）
+（__probe：「$A」，2 ÷ 3）
+（__probe：「$S」，此之舍入模式）
+此之（舍入模式：「截断」）
+（__probe：「$A」，2 ÷ 3）
+（__probe：「$A」，（四舍五入：2.5，0））
+此之舍入模式`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnString("截断"),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"0.33333333", "*exec.ZnDecimal"},
+					{"0.33333333333333333333", "*exec.ZnDecimal"},
+					{"0.667", "*exec.ZnDecimal"},
+					{"0.66666667", "*exec.ZnDecimal"},
+					{"0.66666666", "*exec.ZnDecimal"},
+					{"2", "*exec.ZnDecimal"},
+				},
+				"$S": {
+					{"20", "*exec.ZnDecimal"},
+					{"8", "*exec.ZnDecimal"},
+					{"「四舍五入」", "*exec.ZnString"},
+				},
+			},
+		},
+		{
+			name: "settings inside branch blocks",
+			program: `
+如果真：
+	此之（精度：2）
+	（__probe：「$A」，1 ÷ 3）
+（__probe：「$A」，1 ÷ 3）
+尝试：
+	此之精度为3
+	抛出「中断」
+捕获：
+	（__probe：「$A」，1 ÷ 3）
+对于1：
+	为1：
+		此之（精度：4）
+		（__probe：「$A」，1 ÷ 3）
+	否则：
+		（__probe：「$A」，0）
+（__probe：「$A」，1 ÷ 3）
+此之精度`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(8, 0),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"0.33", "*exec.ZnDecimal"},
+					{"0.33333333", "*exec.ZnDecimal"},
+					{"0.33333333", "*exec.ZnDecimal"},
+					{"0.3333", "*exec.ZnDecimal"},
+					{"0.33333333", "*exec.ZnDecimal"},
+				},
+			},
+		},
+		{
+			name: "invalid settings",
+			program: `
+尝试：
+	此之（精度：0）
+捕获E：
+	（__probe：「$E」，E之代码）
+尝试：
+	此之（舍入模式：「五入」）
+捕获E：
+	（__probe：「$E」，E之代码）
+此之精度`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(8, 0),
+			expProbe: map[string][][]string{
+				"$E": {
+					{"「2606」", "*exec.ZnString"},
+					{"「2605」", "*exec.ZnString"},
+				},
+			},
+		},
+	}
+	for _, tt := range suites {
+		assertSuite(t, tt)
+	}
+}
+
+func Test_ChineseNumerals(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "numerals, magnitude suffixes and percentages",
+			program: `
+令甲为三千五百
+（__probe：「$A」，甲）
+（__probe：「$A」，一万零五 + 1.2万）
+（__probe：「$A」，3亿）
+（__probe：「$A」，百分之十五 * 200）
+（__probe：「$A」，15% * 200）
+（__probe：「$A」，7%3）
+（__probe：「$A」，负三点一四）
+令三角形为十五
+三角形之绝对值`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnDecimalFromInt(15, 0),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"3500", "*exec.ZnDecimal"},
+					{"22005", "*exec.ZnDecimal"},
+					{"300000000", "*exec.ZnDecimal"},
+					{"30.00", "*exec.ZnDecimal"},
+					{"30.00", "*exec.ZnDecimal"},
+					{"1", "*exec.ZnDecimal"},
+					{"-3.14", "*exec.ZnDecimal"},
+				},
+			},
+		},
+	}
+
+	for _, suite := range suites {
+		assertSuite(t, suite)
+	}
+}
+
+func Test_DecimalFormat(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "format decimals as strings",
+			program: `
+令金额为12345.675
+（__probe：「$A」，金额之大写金额）
+（__probe：「$A」，金额之（大写金额：「截断」））
+（__probe：「$A」，1234之中文数字）
+（__probe：「$A」，金额之千分位）
+（__probe：「$A」，金额之（千分位：2））
+（__probe：「$A」，金额之（保留小数：1））
+（__probe：「$A」，3之（保留小数：2））
+（__probe：「$A」，0.155之百分比）
+（__probe：「$A」，0.155之（百分比：0，「向下」））
+此之（舍入模式：「四舍六入五成双」）
+金额之（保留小数：2）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnString("12345.68"),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"「壹万贰仟叁佰肆拾伍元陆角捌分」", "*exec.ZnString"},
+					{"「壹万贰仟叁佰肆拾伍元陆角柒分」", "*exec.ZnString"},
+					{"「一千二百三十四」", "*exec.ZnString"},
+					{"「12,345.675」", "*exec.ZnString"},
+					{"「12,345.68」", "*exec.ZnString"},
+					{"「12345.7」", "*exec.ZnString"},
+					{"「3.00」", "*exec.ZnString"},
+					{"「15.5%」", "*exec.ZnString"},
+					{"「15%」", "*exec.ZnString"},
+				},
+			},
+		},
+	}
+
+	for _, suite := range suites {
+		assertSuite(t, suite)
+	}
+}
+
+func Test_DateTime(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "dates, times & durations",
+			program: `
+令甲为（日期：「2024年1月31日」）
+（__probe：「$A」，甲之（加月数：1））
+（__probe：「$A」，甲之（加工作日：3））
+（__probe：「$A」，甲之（加天数：1万））
+（__probe：「$A」，（日期：2024，3，5）之（相差天数：甲））
+（__probe：「$A」，甲之（格式化：「YYYY-MM-DD 星期W」））
+令乙为（时刻：「2024-03-05 14:30」，「UTC」）
+令丙为乙之（加时长：（时长：「1天2小时」））
+（__probe：「$A」，丙）
+（__probe：「$A」，丙之（相差：乙））
+（__probe：「$A」，丙之（转换时区：「UTC+8」）之时）
+（__probe：「$A」，（时长：90，「分钟」）之小时数）
+（__probe：「$A」，丙大于乙）
+甲等于（日期：「2024-01-31」）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnBool(true),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"2024年2月29日", "*exec.ZnDate"},
+					{"2024年2月5日", "*exec.ZnDate"},
+					{"2051年6月18日", "*exec.ZnDate"},
+					{"34", "*exec.ZnDecimal"},
+					{"「2024-01-31 星期三」", "*exec.ZnString"},
+					{"2024年3月6日 16:30:00", "*exec.ZnTime"},
+					{"1天2小时", "*exec.ZnDuration"},
+					{"0", "*exec.ZnDecimal"},
+					{"1.5", "*exec.ZnDecimal"},
+					{"真", "*exec.ZnBool"},
+				},
+			},
+		},
+	}
+
+	for _, suite := range suites {
+		assertSuite(t, suite)
+	}
+}
+
+func Test_Money(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "money literals, arithmetic & allocation",
+			program: `
+令价格为¥12.50
+令运费为3.2元
+（__probe：「$A」，价格 ＋ 运费）
+（__probe：「$A」，价格 × 3）
+（__probe：「$A」，价格 ÷ 4）
+（__probe：「$A」，（货币：123456789） ÷ 7）
+（__probe：「$A」，价格 ÷ ¥2.5）
+（__probe：「$A」，三千五百元）
+（__probe：「$A」，（货币：99.9，「欧元」））
+令总额为¥100
+（__probe：「$A」，总额之（平分：3））
+（__probe：「$A」，总额之（按比例分配：3，2，1））
+令单价为¥12.345
+（__probe：「$A」，单价之（舍入））
+（__probe：「$A」，单价之（舍入：「截断」））
+令美金为100美元
+（__probe：「$A」，美金之（换算：7.1，「人民币」））
+（__probe：「$A」，美金之币种）
+价格大于运费`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnBool(true),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"¥15.70", "*exec.ZnMoney"},
+					{"¥37.50", "*exec.ZnMoney"},
+					{"¥3.125", "*exec.ZnMoney"},
+					{"¥17636684.1428571429", "*exec.ZnMoney"},
+					{"5.0000000", "*exec.ZnDecimal"},
+					{"¥3500.00", "*exec.ZnMoney"},
+					{"99.90欧元", "*exec.ZnMoney"},
+					{"【¥33.34，¥33.33，¥33.33】", "*exec.ZnArray"},
+					{"【¥50.00，¥33.33，¥16.67】", "*exec.ZnArray"},
+					{"¥12.35", "*exec.ZnMoney"},
+					{"¥12.34", "*exec.ZnMoney"},
+					{"¥710.00", "*exec.ZnMoney"},
+					{"「USD」", "*exec.ZnString"},
+				},
+			},
+		},
+	}
+
+	for _, suite := range suites {
+		assertSuite(t, suite)
+	}
+}
+
+func Test_Fraction(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "exact division & fraction arithmetic",
+			program: `
+令甲为（分数：1，3）
+令乙为1
+（__probe：「$A」，甲 × 3）
+（__probe：「$A」，1 ÷ 3 × 3）
+（__probe：「$A」，甲 ＋ 0.5）
+（__probe：「$A」，乙之（精确除：3） ＋ （精确除：2，3））
+（__probe：「$A」，－甲 ÷ 2）
+（__probe：「$A」，（分数：「7/2」） % 1）
+（__probe：「$A」，甲之（近似值：4））
+（__probe：「$A」，（分数：「2/3」）之（近似值：2，「截断」））
+（__probe：「$A」，甲之分母）
+（__probe：「$A」，0.5 等于 （分数：「1/2」））
+甲 小于 0.34`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnBool(true),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"1", "*exec.ZnFraction"},
+					{"0.99999999", "*exec.ZnDecimal"},
+					{"5/6", "*exec.ZnFraction"},
+					{"1", "*exec.ZnFraction"},
+					{"-1/6", "*exec.ZnFraction"},
+					{"1/2", "*exec.ZnFraction"},
+					{"0.3333", "*exec.ZnDecimal"},
+					{"0.66", "*exec.ZnDecimal"},
+					{"3", "*exec.ZnDecimal"},
+					{"真", "*exec.ZnBool"},
+				},
+			},
+		},
+	}
+
+	for _, suite := range suites {
+		assertSuite(t, suite)
+	}
+}
+
+func Test_Match(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "value, list & range arms",
+			program: `
+如何评级？
+	已知得分
+	对于得分：
+		为100：
+			返回「满分」
+		为98，99：
+			返回「接近满分」
+		不小于90且小于98：
+			返回「优秀」
+		不小于60：
+			返回「及格」
+		否则：
+			返回「不及格」
+（__probe：「$A」，（评级：100））
+（__probe：「$A」，（评级：99））
+（__probe：「$A」，（评级：90.5））
+（__probe：「$A」，（评级：60））
+（__probe：「$A」，（评级：3））`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnString("不及格"),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"「满分」", "*exec.ZnString"},
+					{"「接近满分」", "*exec.ZnString"},
+					{"「优秀」", "*exec.ZnString"},
+					{"「及格」", "*exec.ZnString"},
+					{"「不及格」", "*exec.ZnString"},
+				},
+			},
+		},
+		{
+			name: "hashmap shape with binding",
+			program: `
+令订单为【「状态」==「已发货」，「物流」==【「公司」==「顺丰」，「单号」==「SF001」】】
+对于订单：
+	为【「状态」==「待付款」】：
+		（__probe：「$A」，「待付款」）
+	为【「状态」==「已发货」，「物流」==【「单号」==单号】】：
+		（__probe：「$A」，单号）
+对于订单：
+	为【「优惠券」==券】：
+		（__probe：「$A」，券）
+	否则：
+		（__probe：「$A」，「无优惠」）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"「SF001」", "*exec.ZnString"},
+					{"「无优惠」", "*exec.ZnString"},
+				},
+			},
+		},
+		{
+			name: "bindings of failed patterns are discarded",
+			program: `
+令A为【「a」== 9，「c」== 3】
+对于A：
+	为【「a」== X，「b」== 1】，【「c」== Y】：
+		（__probe：「$A」，Y）
+尝试：
+	（__probe：「$A」，X）
+捕获：
+	（__probe：「$A」，「X未定义」）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"3", "*exec.ZnDecimal"},
+					{"「X未定义」", "*exec.ZnString"},
+				},
+			},
+		},
+		{
+			name: "class type arms",
+			program: `
+定义动物：
+	其名为「动物」
+定义狗 继承 动物：
+	其名为「狗」
+定义猫：
+	其名为「猫」
+令甲成为狗
+令乙成为猫
+对于甲：
+	属于猫：
+		（__probe：「$A」，「猫」）
+	属于动物：
+		（__probe：「$A」，「动物」）
+对于「喵」：
+	属于数值：
+		（__probe：「$A」，「数值」）
+	属于文本：
+		（__probe：「$A」，「文本」）
+对于乙：
+	属于狗：
+		（__probe：「$A」，「狗」）
+	属于猫：
+		（__probe：「$A」，「猫」）`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnNull(),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"「动物」", "*exec.ZnString"},
+					{"「文本」", "*exec.ZnString"},
+					{"「猫」", "*exec.ZnString"},
+				},
+			},
+		},
+	}
+
+	for _, suite := range suites {
+		assertSuite(t, suite)
+	}
+}
+
+func Test_VariadicParams(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "collect rest params & spread arrays",
+			program: `
+如何累计？
+	已知首项，余项……
+	令和为首项
+	以项遍历余项：
+		和为和＋项
+	返回和
+令列表为【2，3，4】
+（__probe：「$A」，（累计：1））
+（__probe：「$A」，（累计：1，2，3））
+（__probe：「$A」，（累计：列表……））
+（__probe：「$A」，（累计：10，列表……，【5】……））
+（累计：列表……） 等于 9`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnBool(true),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"1", "*exec.ZnDecimal"},
+					{"6", "*exec.ZnDecimal"},
+					{"9", "*exec.ZnDecimal"},
+					{"24", "*exec.ZnDecimal"},
+				},
+			},
+		},
+		{
+			name: "variadic methods & spread to native methods",
+			program: `
+定义计算器：
+	如何收集？
+		已知数组……
+		返回数组
+令C成为计算器
+令列表为【2，3】
+（__probe：「$A」，C之（收集））
+（__probe：「$A」，C之（收集：1，列表……））
+（__probe：「$A」，列表之（添加：列表……））
+列表 等于 【2，3，2，3】`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnBool(true),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"【】", "*exec.ZnArray"},
+					{"【1，2，3】", "*exec.ZnArray"},
+					{"【2，3，2，3】", "*exec.ZnArray"},
+				},
+			},
+		},
+	}
+
+	for _, suite := range suites {
+		assertSuite(t, suite)
+	}
+}
+
+func Test_DefaultAndNamedParams(t *testing.T) {
+	suites := []programOKSuite{
+		{
+			name: "default values & named params of functions",
+			program: `
+令基数为1
+如何计算税费？
+	已知金额，税率为0.06，附加为金额×0.01＋基数
+	返回金额×税率＋附加
+（__probe：「$A」，（计算税费：100））
+（__probe：「$A」，（计算税费：100，0.13））
+（__probe：「$A」，（计算税费：金额 == 100，税率 == 0.13））
+（__probe：「$A」，（计算税费：税率 == 0.1，金额 == 200））
+基数为0
+（__probe：「$A」，（计算税费：100））
+（计算税费：100，附加 == 0） 等于 6`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnBool(true),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"8.00", "*exec.ZnDecimal"},
+					{"15.00", "*exec.ZnDecimal"},
+					{"15.00", "*exec.ZnDecimal"},
+					{"23.00", "*exec.ZnDecimal"},
+					{"7.00", "*exec.ZnDecimal"},
+				},
+			},
+		},
+		{
+			name: "default values & named params of methods",
+			program: `
+定义账户：
+	如何存入？
+		已知数额，备注为「无」，标签……
+		返回备注
+令甲成为账户
+（__probe：「$A」，甲之（存入：1））
+（__probe：「$A」，甲之（存入：1，「工资」，「A」））
+（__probe：「$A」，甲之（存入：数额 == 1，备注 == 「奖金」））
+甲之（存入：备注 == 「利息」，数额 == 1） 等于 「利息」`,
+			symbols:        map[string]ZnValue{},
+			expReturnValue: NewZnBool(true),
+			expProbe: map[string][][]string{
+				"$A": {
+					{"「无」", "*exec.ZnString"},
+					{"「工资」", "*exec.ZnString"},
+					{"「奖金」", "*exec.ZnString"},
+				},
+			},
+		},
+	}
+
+	for _, suite := range suites {
+		assertSuite(t, suite)
+	}
+}
+
 func assertSuite(t *testing.T, suite programOKSuite) {
 	t.Run(suite.name, func(t *testing.T) {
 		ctx := NewContext()
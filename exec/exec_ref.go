@@ -5,27 +5,55 @@ import (
 	"github.com/reg0007/Zn/syntax"
 )
 
-type funcExecutor func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error)
+// FuncExecutor - the execution logic of a function, getter, method or constructor.
+// For getters & methods, the object itself could be retrieved by `scope.GetTargetThis()`.
+type FuncExecutor func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error)
 
 type paramHandler func(ctx *Context, scope *FuncScope, params []ZnValue) *error.Error
 
+// ZnNamedParam - a param passed by name (e.g. 税率 == 0.13), which is bound to the param of the
+// same name by paramHandler. Only user-defined functions & methods accept named params.
+type ZnNamedParam struct {
+	*ZnObject
+	Name  string
+	Value ZnValue
+}
+
+// NewZnNamedParam -
+func NewZnNamedParam(name string, value ZnValue) *ZnNamedParam {
+	return &ZnNamedParam{
+		Name:     name,
+		Value:    value,
+		ZnObject: NewZnObject(nil),
+	}
+}
+
+// String - e.g. 税率 == 0.13
+func (zn *ZnNamedParam) String() string {
+	return zn.Name + " == " + zn.Value.String()
+}
+
 // ClosureRef - aka. Closure Exection Reference
 // This structure wraps the execution logic inside the closure
 // statically
 type ClosureRef struct {
 	Name         string
 	ParamHandler paramHandler // bind & validate params before actual execution
-	Executor     funcExecutor // actual execution logic
+	Executor     FuncExecutor // actual execution logic
+	// lexScope - the scope where the closure is defined. When executing the closure,
+	// its FuncScope derives from lexScope instead of the caller's scope, so that
+	// free variables are resolved lexically. (nil for native functions)
+	lexScope Scope
 }
 
-// NewClosureRef -
-func NewClosureRef(name string, paramTags []*syntax.ID, stmtBlock *syntax.BlockStmt) *ClosureRef {
+// NewClosureRef - create a closure that captures the defining scope
+func NewClosureRef(name string, paramTags []*syntax.ParamItem, stmtBlock *syntax.BlockStmt, scope Scope) *ClosureRef {
 
 	var executor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
 		// iterate block round I - function hoisting
 		for _, stmtI := range stmtBlock.Children {
 			if v, ok := stmtI.(*syntax.FunctionDeclareStmt); ok {
-				fn := NewZnFunction(v)
+				fn := NewZnFunction(v, scope)
 				if err := bindValue(ctx, scope, v.FuncName.GetLiteral(), fn, false); err != nil {
 					return nil, err
 				}
@@ -49,15 +77,86 @@ func NewClosureRef(name string, paramTags []*syntax.ID, stmtBlock *syntax.BlockS
 	}
 
 	var paramHandler = func(ctx *Context, scope *FuncScope, params []ZnValue) *error.Error {
-		// check param length
-		if len(params) != len(paramTags) {
-			return error.MismatchParamLengthError(len(paramTags), len(params))
+		// the variadic param (if exists) collects all rest params into an array
+		fixedLen := len(paramTags)
+		isVariadic := fixedLen > 0 && paramTags[fixedLen-1].IsVariadic
+		if isVariadic {
+			fixedLen--
+		}
+		hasDefault := false
+		for _, tag := range paramTags[:fixedLen] {
+			if tag.DefaultValue != nil {
+				hasDefault = true
+			}
+		}
+		// #1. fill positional params first, and then named params (if any)
+		slots := make([]ZnValue, fixedLen)
+		rest := []ZnValue{}
+		positionalLen := 0
+		hasNamed := false
+		for _, param := range params {
+			named, ok := param.(*ZnNamedParam)
+			if !ok {
+				if positionalLen < fixedLen {
+					slots[positionalLen] = param
+				} else {
+					rest = append(rest, param)
+				}
+				positionalLen++
+				continue
+			}
+			hasNamed = true
+			pos := -1
+			for tIdx, tag := range paramTags[:fixedLen] {
+				if tag.ID.GetLiteral() == named.Name {
+					pos = tIdx
+					break
+				}
+			}
+			if pos < 0 {
+				return error.UnknownParamName(named.Name)
+			}
+			if slots[pos] != nil {
+				return error.DuplicateParamName(named.Name)
+			}
+			slots[pos] = named.Value
+		}
+
+		// #2. check param length
+		if !hasDefault && !hasNamed {
+			if isVariadic && positionalLen < fixedLen {
+				return error.LeastParamsError(fixedLen)
+			}
+			if !isVariadic && positionalLen != fixedLen {
+				return error.MismatchParamLengthError(fixedLen, positionalLen)
+			}
+		}
+		if !isVariadic && len(rest) > 0 {
+			return error.MostParamsError(fixedLen)
 		}
 
-		// bind params (as variable) to function scope
-		for idx, param := range params {
-			paramTag := paramTags[idx].GetLiteral()
-			if err := bindValue(ctx, scope, paramTag, param, false); err != nil {
+		// #3. bind params (as variable) to function scope; default values are evaluated
+		// inside function scope, thus previous params could be referred.
+		for idx, tag := range paramTags[:fixedLen] {
+			paramTag := tag.ID.GetLiteral()
+			value := slots[idx]
+			if value == nil {
+				if tag.DefaultValue == nil {
+					return error.MissingParam(paramTag)
+				}
+				val, err := evalExpression(ctx, scope, tag.DefaultValue)
+				if err != nil {
+					return err
+				}
+				value = val
+			}
+			if err := bindValue(ctx, scope, paramTag, value, false); err != nil {
+				return err
+			}
+		}
+		if isVariadic {
+			paramTag := paramTags[fixedLen].ID.GetLiteral()
+			if err := bindValue(ctx, scope, paramTag, NewZnArray(rest), false); err != nil {
 				return err
 			}
 		}
@@ -68,11 +167,12 @@ func NewClosureRef(name string, paramTags []*syntax.ID, stmtBlock *syntax.BlockS
 		Name:         name,
 		ParamHandler: paramHandler,
 		Executor:     executor,
+		lexScope:     scope,
 	}
 }
 
 // NewNativeClosureRef - define native function
-func NewNativeClosureRef(name string, executor funcExecutor) *ClosureRef {
+func NewNativeClosureRef(name string, executor FuncExecutor) *ClosureRef {
 	return &ClosureRef{
 		Name:         name,
 		ParamHandler: nil,
@@ -82,51 +182,103 @@ func NewNativeClosureRef(name string, executor funcExecutor) *ClosureRef {
 
 // Exec - exec function
 func (cr *ClosureRef) Exec(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
-	// handle params
+	// handle params - it's done before entering the call, thus binding errors
+	// (e.g. unknown param names) are reported at the call site
 	if cr.ParamHandler != nil {
 		if err := cr.ParamHandler(ctx, scope, params); err != nil {
+			ctx.traceError(err)
 			return nil, err
 		}
+	} else if err := rejectNamedParams(params); err != nil {
+		ctx.traceError(err)
+		return nil, err
+	}
+	if err := ctx.enterCall(cr); err != nil {
+		return nil, err
 	}
+	defer ctx.exitCall(cr)
 	// do execution
-	return cr.Executor(ctx, scope, params)
+	val, err := cr.Executor(ctx, scope, params)
+	if err != nil {
+		ctx.traceError(err)
+	}
+	return val, err
+}
+
+// rejectNamedParams - native functions (and scope methods) only accept positional params
+func rejectNamedParams(params []ZnValue) *error.Error {
+	for _, param := range params {
+		if named, ok := param.(*ZnNamedParam); ok {
+			return error.NamedParamNotSupported(named.Name)
+		}
+	}
+	return nil
+}
+
+// newFuncScope - create a FuncScope to execute the closure. For native functions
+// (that have no lexical scope), the caller's scope is used as parent instead.
+func (cr *ClosureRef) newFuncScope(callerScope Scope, targetThis ZnValue) *FuncScope {
+	if cr.lexScope != nil {
+		return NewFuncScope(cr.lexScope, targetThis)
+	}
+	return NewFuncScope(callerScope, targetThis)
 }
 
 // ClassRef -
 type ClassRef struct {
 	Name        string
-	Constructor funcExecutor           // a function to initialize all properties
+	Parent      *ClassRef              // parent class (nil if the class doesn't inherit from another one)
+	Constructor FuncExecutor           // a function to initialize all properties
 	GetterList  map[string]*ClosureRef // stores defined getters inside the class
 	MethodList  map[string]*ClosureRef // stores defined methods inside the class
+	// propList - property declarations (with initial values) of the class itself
+	propList []*syntax.PropertyDeclareStmt
+	// constructorIDList - property names of constructor params (是为XX，YY).
+	// if not declared, it's inherited from parent class.
+	constructorIDList []*syntax.ID
 }
 
-// NewClassRef -
-func NewClassRef(name string, classNode *syntax.ClassDeclareStmt) *ClassRef {
+// NewClassRef - all getters & methods of the class capture the scope where the class is defined.
+// parent may be nil if the class doesn't inherit from another one.
+func NewClassRef(name string, classNode *syntax.ClassDeclareStmt, parent *ClassRef, scope Scope) *ClassRef {
 	ref := &ClassRef{
-		Name:        name,
-		Constructor: nil,
-		GetterList:  map[string]*ClosureRef{},
-		MethodList:  map[string]*ClosureRef{},
+		Name:              name,
+		Parent:            parent,
+		Constructor:       nil,
+		GetterList:        map[string]*ClosureRef{},
+		MethodList:        map[string]*ClosureRef{},
+		propList:          classNode.PropertyList,
+		constructorIDList: classNode.ConstructorIDList,
+	}
+	if len(ref.constructorIDList) == 0 && parent != nil {
+		ref.constructorIDList = parent.constructorIDList
 	}
 
 	// define default constrcutor
 	var constructor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
 		obj := NewZnObject(ref)
-		// init prop list
-		for _, propPair := range classNode.PropertyList {
-			propID := propPair.PropertyID.GetLiteral()
-			expr, err := evalExpression(ctx, scope, propPair.InitValue)
-			if err != nil {
-				return nil, err
+		// init prop list - from the top-most parent class to the class itself,
+		// thus initial values of parent properties could be overridden
+		classChain := []*ClassRef{}
+		for cr := ref; cr != nil; cr = cr.Parent {
+			classChain = append([]*ClassRef{cr}, classChain...)
+		}
+		for _, cr := range classChain {
+			for _, propPair := range cr.propList {
+				propID := propPair.PropertyID.GetLiteral()
+				expr, err := evalExpression(ctx, scope, propPair.InitValue)
+				if err != nil {
+					return nil, err
+				}
+				obj.PropList[propID] = expr
 			}
-			obj.PropList[propID] = expr
 		}
 		// constructor: set some properties' value
-		if len(params) != len(classNode.ConstructorIDList) {
-			return nil, error.MismatchParamLengthError(len(params), len(classNode.ConstructorIDList))
+		if len(params) != len(ref.constructorIDList) {
+			return nil, error.MismatchParamLengthError(len(params), len(ref.constructorIDList))
 		}
 		for idx, objParam := range params {
-			propID := classNode.ConstructorIDList[idx].GetLiteral()
+			propID := ref.constructorIDList[idx].GetLiteral()
 			obj.PropList[propID] = objParam
 		}
 
@@ -138,19 +290,82 @@ func NewClassRef(name string, classNode *syntax.ClassDeclareStmt) *ClassRef {
 	// add getters
 	for _, gNode := range classNode.GetterList {
 		getterTag := gNode.GetterName.GetLiteral()
-		ref.GetterList[getterTag] = NewClosureRef(getterTag, []*syntax.ID{}, gNode.ExecBlock)
+		ref.GetterList[getterTag] = NewClosureRef(getterTag, []*syntax.ParamItem{}, gNode.ExecBlock, scope)
 	}
 
 	// add methods
 	for _, mNode := range classNode.MethodList {
 		mTag := mNode.FuncName.GetLiteral()
-		ref.MethodList[mTag] = NewClosureRef(mTag, mNode.ParamList, mNode.ExecBlock)
+		ref.MethodList[mTag] = NewClosureRef(mTag, mNode.ParamList, mNode.ExecBlock, scope)
 	}
 
 	return ref
 }
 
+// NewNativeClassRef - define a class whose logic is implemented in Go.
+// When constructing a new object, an empty instance of the class is set as
+// "this" of the scope, and initializer (if not nil) is executed to set its
+// properties (via `scope.GetTargetThis()`). The return value of initializer is ignored.
+func NewNativeClassRef(name string, initializer FuncExecutor) *ClassRef {
+	ref := &ClassRef{
+		Name:       name,
+		GetterList: map[string]*ClosureRef{},
+		MethodList: map[string]*ClosureRef{},
+	}
+	ref.Constructor = func(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
+		obj := NewZnObject(ref)
+		if initializer != nil {
+			initScope := NewFuncScope(scope, obj)
+			if _, err := initializer(ctx, initScope, params); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	}
+	return ref
+}
+
+// AddGetter - add a native getter to the class
+func (cr *ClassRef) AddGetter(name string, executor FuncExecutor) {
+	cr.GetterList[name] = NewNativeClosureRef(name, executor)
+}
+
+// AddMethod - add a native method to the class
+func (cr *ClassRef) AddMethod(name string, executor FuncExecutor) {
+	cr.MethodList[name] = NewNativeClosureRef(name, executor)
+}
+
 // Construct - yield new instance of this class
 func (cr *ClassRef) Construct(ctx *Context, scope *FuncScope, params []ZnValue) (ZnValue, *error.Error) {
 	return cr.Constructor(ctx, scope, params)
 }
+
+// findMethod - find method from the class itself, then its parent classes
+func (cr *ClassRef) findMethod(name string) (*ClosureRef, bool) {
+	for ref := cr; ref != nil; ref = ref.Parent {
+		if methodRef, ok := ref.MethodList[name]; ok {
+			return methodRef, true
+		}
+	}
+	return nil, false
+}
+
+// findGetter - find getter from the class itself, then its parent classes
+func (cr *ClassRef) findGetter(name string) (*ClosureRef, bool) {
+	for ref := cr; ref != nil; ref = ref.Parent {
+		if getterRef, ok := ref.GetterList[name]; ok {
+			return getterRef, true
+		}
+	}
+	return nil, false
+}
+
+// findParent - find parent class (or ancestor class) by name
+func (cr *ClassRef) findParent(name string) (*ClassRef, bool) {
+	for ref := cr.Parent; ref != nil; ref = ref.Parent {
+		if ref.Name == name {
+			return ref, true
+		}
+	}
+	return nil, false
+}
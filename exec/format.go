@@ -0,0 +1,188 @@
+package exec
+
+import (
+	"strings"
+
+	"github.com/reg0007/Zn/error"
+)
+
+// chinese digits & units for formatting, e.g. 一千二百三十四，壹仟贰佰叁拾肆
+var (
+	chineseDigits      = []rune("零一二三四五六七八九")
+	chineseUpperDigits = []rune("零壹贰叁肆伍陆柒捌玖")
+	chineseUnits       = []string{"", "十", "百", "千"}
+	chineseUpperUnits  = []string{"", "拾", "佰", "仟"}
+)
+
+// checkFormatDigits - the plain text of a decimal has |exp| digits at least, thus decimals
+// with huge exponents (e.g. 1E+2000000000) are rejected before formatting to avoid exhausting
+// the memory.
+func checkFormatDigits(zd *ZnDecimal) *error.Error {
+	if zd.exp > maxDigits || zd.exp < -maxDigits {
+		return error.DigitsOutOfRange(maxDigits)
+	}
+	return nil
+}
+
+// splitDecimal - split the absolute value of a decimal into integer & fraction digits,
+// e.g. -12.50 -> ("12", "50"), 300 -> ("300", "")
+func splitDecimal(zd *ZnDecimal) (string, string) {
+	txt := strings.TrimPrefix(zd.plainString(), "-")
+	parts := strings.SplitN(txt, ".", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// formatChineseInteger - format integer digits to Chinese, e.g. 10205 -> 一万零二百零五
+func formatChineseInteger(digits string, numerals []rune, units []string) string {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return string(numerals[0])
+	}
+	// pad lead zeros, thus digits could be split into groups of 4 digits
+	if pad := len(digits) % 4; pad > 0 {
+		digits = strings.Repeat("0", 4-pad) + digits
+	}
+	groupNum := len(digits) / 4
+
+	var sb strings.Builder
+	needZero := false
+	wanUsed := false
+	for i := 0; i < groupNum; i++ {
+		// index of the group from the lowest: "", 万，亿，万亿，亿亿, ...
+		idx := groupNum - 1 - i
+		group := digits[i*4 : i*4+4]
+		if group == "0000" {
+			// e.g. 一亿（零万）零五
+			needZero = sb.Len() > 0
+		} else {
+			for p, ch := range group {
+				if ch == '0' {
+					// zeros between digits, e.g. 一千零五，一万零五
+					needZero = needZero || sb.Len() > 0
+					continue
+				}
+				if needZero {
+					sb.WriteRune(numerals[0])
+					needZero = false
+				}
+				sb.WriteRune(numerals[ch-'0'])
+				sb.WriteString(units[3-p])
+			}
+			// tail zeros of a group won't be displayed, e.g. 一千二百万一千
+			needZero = false
+			if idx%2 == 1 {
+				sb.WriteString("万")
+				wanUsed = true
+			}
+		}
+		// 亿 is shared with the 万 group before, e.g. 一万二千亿，三万亿
+		if idx%2 == 0 {
+			if idx > 0 && (group != "0000" || wanUsed) {
+				sb.WriteString(strings.Repeat("亿", idx/2))
+			}
+			wanUsed = false
+		}
+	}
+	return sb.String()
+}
+
+// formatChineseNumeral - format decimal to Chinese numerals, e.g. 1234.5 -> 一千二百三十四点五, 15 -> 十五
+func formatChineseNumeral(zd *ZnDecimal) string {
+	intPart, fracPart := splitDecimal(zd)
+
+	txt := formatChineseInteger(intPart, chineseDigits, chineseUnits)
+	// omit the leading 一 of 一十, e.g. 十五，十万
+	if strings.HasPrefix(txt, "一十") {
+		txt = strings.TrimPrefix(txt, "一")
+	}
+	if fracPart != "" {
+		frac := []rune{}
+		for _, ch := range fracPart {
+			frac = append(frac, chineseDigits[ch-'0'])
+		}
+		txt = txt + "点" + string(frac)
+	}
+	if zd.co.Sign() < 0 {
+		txt = "负" + txt
+	}
+	return txt
+}
+
+// formatChineseAmount - format decimal to uppercase Chinese amount (人民币大写),
+// e.g. 12345.67 -> 壹万贰仟叁佰肆拾伍元陆角柒分, 100 -> 壹佰元整
+//
+// The amount is rounded to 分 (i.e. 2 decimal places) by the given rounding mode first.
+func formatChineseAmount(arith *Arith, zd *ZnDecimal, mode RoundingMode) (string, *error.Error) {
+	rounded, err := arith.Round(zd, 2, mode)
+	if err != nil {
+		return "", err
+	}
+	if err = checkFormatDigits(rounded); err != nil {
+		return "", err
+	}
+	intPart, fracPart := splitDecimal(rounded)
+	jiao, fen := fracPart[0]-'0', fracPart[1]-'0'
+
+	var sb strings.Builder
+	if rounded.co.Sign() < 0 {
+		sb.WriteString("负")
+	}
+	if intPart != "0" {
+		sb.WriteString(formatChineseInteger(intPart, chineseUpperDigits, chineseUpperUnits))
+		sb.WriteString("元")
+	}
+	if jiao == 0 && fen == 0 {
+		if intPart == "0" {
+			sb.WriteString("零元")
+		}
+		sb.WriteString("整")
+		return sb.String(), nil
+	}
+	if jiao > 0 {
+		sb.WriteRune(chineseUpperDigits[jiao])
+		sb.WriteString("角")
+	} else if intPart != "0" {
+		// e.g. 壹元零伍分
+		sb.WriteRune(chineseUpperDigits[0])
+	}
+	if fen > 0 {
+		sb.WriteRune(chineseUpperDigits[fen])
+		sb.WriteString("分")
+	}
+	return sb.String(), nil
+}
+
+// formatThousands - format decimal with thousands separators, e.g. -1234567.89 -> -1,234,567.89
+func formatThousands(zd *ZnDecimal) string {
+	intPart, fracPart := splitDecimal(zd)
+
+	var sb strings.Builder
+	if zd.co.Sign() < 0 {
+		sb.WriteString("-")
+	}
+	for idx, ch := range intPart {
+		if idx > 0 && (len(intPart)-idx)%3 == 0 {
+			sb.WriteRune(',')
+		}
+		sb.WriteRune(ch)
+	}
+	if fracPart != "" {
+		sb.WriteString(".")
+		sb.WriteString(fracPart)
+	}
+	return sb.String()
+}
+
+// formatPercent - format decimal as percentage, e.g. 0.155 -> 15.5%
+func formatPercent(zd *ZnDecimal) string {
+	percent := copyZnDecimal(zd)
+	percent.exp += 2
+	// avoid tail zeros of 0, e.g. 000%
+	if percent.co.Sign() == 0 {
+		percent.exp = 0
+	}
+	return percent.plainString() + "%"
+}
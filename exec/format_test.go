@@ -0,0 +1,86 @@
+package exec
+
+import (
+	"testing"
+)
+
+func TestFormat_ChineseNumeral(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect string
+	}{
+		{"0", "零"},
+		{"15", "十五"},
+		{"20", "二十"},
+		{"1010", "一千零一十"},
+		{"10205", "一万零二百零五"},
+		{"100000", "十万"},
+		{"12001000", "一千二百万一千"},
+		{"100000005", "一亿零五"},
+		{"1234.5", "一千二百三十四点五"},
+		{"-0.05", "负零点零五"},
+		{"1.2E12", "一万二千亿"},
+		{"3E12", "三万亿"},
+		{"3000100000000", "三万零一亿"},
+	}
+
+	for _, tt := range cases {
+		d, _ := NewZnDecimal(tt.input)
+		if got := formatChineseNumeral(d); got != tt.expect {
+			t.Errorf("formatChineseNumeral(%s) expect -> %s, got -> %s", tt.input, tt.expect, got)
+		}
+	}
+}
+
+func TestFormat_ChineseAmount(t *testing.T) {
+	cases := []struct {
+		input  string
+		mode   RoundingMode
+		expect string
+	}{
+		{"12345.67", RoundHalfUp, "壹万贰仟叁佰肆拾伍元陆角柒分"},
+		{"100", RoundHalfUp, "壹佰元整"},
+		{"0", RoundHalfUp, "零元整"},
+		{"0.5", RoundHalfUp, "伍角"},
+		{"0.05", RoundHalfUp, "伍分"},
+		{"100.05", RoundHalfUp, "壹佰元零伍分"},
+		{"-10.5", RoundHalfUp, "负壹拾元伍角"},
+		{"1.005", RoundHalfUp, "壹元零壹分"},
+		{"1.005", RoundHalfEven, "壹元整"},
+		{"1.009", RoundTruncate, "壹元整"},
+		{"100000005", RoundHalfUp, "壹亿零伍元整"},
+	}
+
+	arith := NewArith(defaultPrecision)
+	for _, tt := range cases {
+		d, _ := NewZnDecimal(tt.input)
+		if got, _ := formatChineseAmount(arith, d, tt.mode); got != tt.expect {
+			t.Errorf("formatChineseAmount(%s, %d) expect -> %s, got -> %s", tt.input, tt.mode, tt.expect, got)
+		}
+	}
+}
+
+func TestFormat_ThousandsAndPercent(t *testing.T) {
+	cases := []struct {
+		input     string
+		thousands string
+		percent   string
+	}{
+		{"0", "0", "0%"},
+		{"999", "999", "99900%"},
+		{"1000", "1,000", "100000%"},
+		{"-1234567.891", "-1,234,567.891", "-123456789.1%"},
+		{"0.155", "0.155", "15.5%"},
+		{"0.15", "0.15", "15%"},
+	}
+
+	for _, tt := range cases {
+		d, _ := NewZnDecimal(tt.input)
+		if got := formatThousands(d); got != tt.thousands {
+			t.Errorf("formatThousands(%s) expect -> %s, got -> %s", tt.input, tt.thousands, got)
+		}
+		if got := formatPercent(d); got != tt.percent {
+			t.Errorf("formatPercent(%s) expect -> %s, got -> %s", tt.input, tt.percent, got)
+		}
+	}
+}
@@ -30,6 +30,13 @@ type ZnHashMapIV struct {
 	Index *ZnString
 }
 
+// ZnStringIV - e.g. 「中文」#1, get the n-th character of a string.
+// Since strings are immutable, it could not be on LHS.
+type ZnStringIV struct {
+	Str   *ZnString
+	Index *ZnDecimal
+}
+
 // ZnMemberIV - e.g. A 之 B, it shows member.property access
 type ZnMemberIV struct {
 	RootObject ZnValue
@@ -43,6 +50,16 @@ type ZnMethodIV struct {
 	Params     []ZnValue
 }
 
+// ZnParentIV - access the implementation of parent class on current object,
+// e.g. 父类之（方法：X，Y），父类之属性 (inside methods of the child class)
+type ZnParentIV struct {
+	This     *ZnObject
+	Parent   *ClassRef
+	Member   string
+	Params   []ZnValue
+	IsMethod bool
+}
+
 // ZnScopeMemberIV - e.g. 此之 属性A
 type ZnScopeMemberIV struct {
 	Member string
@@ -78,19 +95,36 @@ func (iv *ZnArrayIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool)
 	return iv.List.Value[idx], nil
 }
 
+// Reduce -
+func (iv *ZnStringIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool) (ZnValue, *error.Error) {
+	if lhs == true {
+		return nil, error.IndexNotAssignable()
+	}
+	idx, err := iv.Index.asInteger()
+	if err != nil {
+		return nil, error.InvalidExprType("integer")
+	}
+	chars := []rune(iv.Str.Value)
+	if idx < 0 || idx >= len(chars) {
+		return nil, error.IndexOutOfRange()
+	}
+	return NewZnString(string(chars[idx])), nil
+}
+
 // Reduce -
 func (iv *ZnHashMapIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool) (ZnValue, *error.Error) {
 	// check data
 	key := iv.Index.Value
+	// iv is on LHS, a new key will be inserted if not exists
+	if lhs == true {
+		iv.List.SetValue(key, input)
+		return input, nil
+	}
+
 	vr, ok := iv.List.Value[key]
 	if !ok {
 		return nil, error.IndexKeyNotFound(key)
 	}
-
-	if lhs == true {
-		iv.List.Value[key] = input
-		return input, nil
-	}
 	return vr, nil
 }
 
@@ -103,7 +137,7 @@ func (iv *ZnMemberIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool)
 		if lhs == true {
 			return nil, error.NewErrorSLOT("Invalid left-hand side in assignment for getter")
 		}
-		fScope := NewFuncScope(scope, iv.RootObject)
+		fScope := getterRef.newFuncScope(scope, iv.RootObject)
 		return getterRef.Exec(ctx, fScope, []ZnValue{})
 	}
 	if lhs == true {
@@ -124,12 +158,61 @@ func (iv *ZnMethodIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool)
 	if err != nil {
 		return nil, err
 	}
-	fScope := NewFuncScope(scope, iv.RootObject)
+	fScope := methodFunc.newFuncScope(scope, iv.RootObject)
 	return methodFunc.Exec(ctx, fScope, iv.Params)
 }
 
+// Reduce -
+func (iv *ZnParentIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool) (ZnValue, *error.Error) {
+	if iv.IsMethod {
+		if lhs == true {
+			return nil, error.NewErrorSLOT("Invalid left-hand side in assignment")
+		}
+		methodRef, ok := iv.Parent.findMethod(iv.Member)
+		if !ok {
+			return nil, error.MethodNotFound(iv.Member)
+		}
+		fScope := methodRef.newFuncScope(scope, iv.This)
+		return methodRef.Exec(ctx, fScope, iv.Params)
+	}
+	// look for property from getter list at first
+	getterRef, found := iv.Parent.findGetter(iv.Member)
+	if found {
+		// when using getter, only RHS (right-hand side) is allowed
+		if lhs == true {
+			return nil, error.NewErrorSLOT("Invalid left-hand side in assignment for getter")
+		}
+		fScope := getterRef.newFuncScope(scope, iv.This)
+		return getterRef.Exec(ctx, fScope, []ZnValue{})
+	}
+	if lhs == true {
+		if err := iv.This.SetProperty(iv.Member, input); err != nil {
+			return nil, err
+		}
+		return input, nil
+	}
+	return iv.This.GetProperty(iv.Member)
+}
+
 // Reduce -
 func (iv *ZnScopeMemberIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool) (ZnValue, *error.Error) {
+	// arith settings are available on all scopes
+	switch iv.Member {
+	case "精度", "舍入模式":
+		// 此之精度为20
+		if lhs == true {
+			if _, err := setScopeArith(ctx, scope, iv.Member, []ZnValue{input}); err != nil {
+				return nil, err
+			}
+			return input, nil
+		}
+	}
+	switch iv.Member {
+	case "精度":
+		return NewZnDecimalFromInt(getArith(ctx, scope).precision, 0), nil
+	case "舍入模式":
+		return NewZnString(getRoundingModeName(getArith(ctx, scope).rounding)), nil
+	}
 	// TODO: general scope method management
 	switch sp := scope.(type) {
 	case *IterateScope:
@@ -141,6 +224,14 @@ func (iv *ZnScopeMemberIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs
 
 // Reduce -
 func (iv *ZnScopeMethodIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool) (ZnValue, *error.Error) {
+	if err := rejectNamedParams(iv.Params); err != nil {
+		return nil, err
+	}
+	// set arith settings of current scope, e.g. 此之（精度：20），此之（舍入模式：「四舍六入五成双」）
+	switch iv.MethodName {
+	case "精度", "舍入模式":
+		return setScopeArith(ctx, scope, iv.MethodName, iv.Params)
+	}
 	switch sp := scope.(type) {
 	case *WhileScope:
 		return sp.execSpecialMethods(iv.MethodName, iv.Params)
@@ -165,7 +256,7 @@ func (iv *ZnPropIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool) (
 		if lhs == true {
 			return nil, error.NewErrorSLOT("Invalid left-hand side in assignment for getter")
 		}
-		fScope := NewFuncScope(scope, targetThis)
+		fScope := getterRef.newFuncScope(scope, targetThis)
 		return getterRef.Exec(ctx, fScope, []ZnValue{})
 	}
 	// look for orinary property
@@ -177,3 +268,35 @@ func (iv *ZnPropIV) Reduce(ctx *Context, scope Scope, input ZnValue, lhs bool) (
 	}
 	return targetThis.GetProperty(iv.Member)
 }
+
+// setScopeArith - set precision or rounding mode of current scope. The setting affects all
+// following statements of current scope (including its child scopes), until the scope ends;
+// or until the block ends if it's set inside a branch block (see evalBranchBlock).
+func setScopeArith(ctx *Context, scope Scope, name string, params []ZnValue) (ZnValue, *error.Error) {
+	as, ok := scope.(arithScope)
+	if !ok {
+		return nil, error.NewErrorSLOT("invalid scope")
+	}
+	if len(params) != 1 {
+		return nil, error.ExactParamsError(1)
+	}
+	current := getArith(ctx, scope)
+	switch name {
+	case "精度":
+		precision, err := getIntegerParam(params[0])
+		if err != nil {
+			return nil, err
+		}
+		if precision <= 0 {
+			return nil, error.InvalidPrecision(precision)
+		}
+		as.setArith(NewArithWithRounding(precision, current.rounding))
+	case "舍入模式":
+		mode, err := getRoundingModeParam(params[0])
+		if err != nil {
+			return nil, err
+		}
+		as.setArith(NewArithWithRounding(current.precision, mode))
+	}
+	return NewZnNull(), nil
+}
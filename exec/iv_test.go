@@ -61,3 +61,45 @@ func TestReduce_Array_RHS_OK(t *testing.T) {
 }
 
 // TODO: add more testcases
+
+func TestReduce_String(t *testing.T) {
+	cases := []struct {
+		name    string
+		str     string
+		index   string
+		lhs     bool
+		expect  ZnValue
+		errCode uint16
+	}{
+		{"get character", "中华人民共和国", "2", false, NewZnString("人"), 0},
+		{"get last character", "Zn语言", "3", false, NewZnString("言"), 0},
+		{"index out of range", "中文", "2", false, nil, 0x2401},
+		{"negative index", "中文", "-1", false, nil, 0x2401},
+		{"non-integer index", "中文", "0.5", false, nil, 0x2301},
+		{"assign to character", "中文", "0", true, nil, 0x2403},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			decimal, _ := NewZnDecimal(tt.index)
+			ctx := NewContext()
+			scope := NewRootScope()
+			iv := ZnStringIV{NewZnString(tt.str), decimal}
+
+			v, err := iv.Reduce(ctx, scope, NewZnString("X"), tt.lhs)
+			if tt.errCode != 0 {
+				if err == nil || err.GetCode() != tt.errCode {
+					t.Errorf("reduce() should return error code %x, got %v", tt.errCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("reduce() should have no error - but error: %s occured", err.Error())
+				return
+			}
+			if !reflect.DeepEqual(v, tt.expect) {
+				t.Errorf("not same: expect=%v, reduced=%v", tt.expect.String(), v.String())
+			}
+		})
+	}
+}
@@ -24,6 +24,9 @@ type BlockScope struct {
 	root      *RootScope
 	parent    Scope
 	symbolMap map[string]SymbolInfo
+	// arith - arith settings of this scope (set by 此之（精度：N）, etc.), nil means
+	// it inherits the settings of parent scope
+	arith *Arith
 }
 
 // GetRoot - get root scope
@@ -49,6 +52,57 @@ func (sb *BlockScope) SetSymbol(name string, value ZnValue, isConstant bool) {
 	}
 }
 
+// resetSymbols - remove symbols declared in this scope except the kept ones. It's called before
+// each iteration of loops, thus variables declared in the loop body (e.g. 令X为1) could be declared
+// again in the next iteration.
+func (sb *BlockScope) resetSymbols(keep ...string) {
+	symbolMap := map[string]SymbolInfo{}
+	for _, name := range keep {
+		if sym, ok := sb.symbolMap[name]; ok {
+			symbolMap[name] = sym
+		}
+	}
+	sb.symbolMap = symbolMap
+}
+
+// GetSymbols - get all symbols defined in this scope (symbols of parent scopes are not included)
+func (sb *BlockScope) GetSymbols() map[string]SymbolInfo {
+	symbols := map[string]SymbolInfo{}
+	for name, sym := range sb.symbolMap {
+		symbols[name] = sym
+	}
+	return symbols
+}
+
+// getArith - get the arith settings of this scope (nil if not set)
+func (sb *BlockScope) getArith() *Arith {
+	return sb.arith
+}
+
+// setArith - set arith settings of this scope, which affects the scope itself and all its children
+func (sb *BlockScope) setArith(arith *Arith) {
+	sb.arith = arith
+}
+
+// arithScope - all scopes (which derive from BlockScope) could have their own arith settings
+type arithScope interface {
+	getArith() *Arith
+	setArith(arith *Arith)
+}
+
+// getArith - get the arith settings of current scope: from the nearest scope (up to RootScope)
+// which has its own settings; otherwise use the settings of Context.
+func getArith(ctx *Context, scope Scope) *Arith {
+	for sp := scope; sp != nil; sp = sp.GetParent() {
+		if as, ok := sp.(arithScope); ok {
+			if arith := as.getArith(); arith != nil {
+				return arith
+			}
+		}
+	}
+	return ctx.arith
+}
+
 // NewBlockScope -
 func NewBlockScope(scope Scope) *BlockScope {
 	return &BlockScope{
@@ -117,6 +171,15 @@ func (rs *RootScope) SetCurrentLine(line int) {
 	rs.currentLine = line
 }
 
+// GetClassRefs - get all classes defined in this program
+func (rs *RootScope) GetClassRefs() map[string]*ClassRef {
+	refs := map[string]*ClassRef{}
+	for name, ref := range rs.classRefMap {
+		refs[name] = ref
+	}
+	return refs
+}
+
 // SetLastValue - set last value
 func (rs *RootScope) SetLastValue(value ZnValue) {
 	rs.lastValue = value
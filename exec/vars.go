@@ -70,6 +70,21 @@ type ZnHashMap struct {
 	KeyOrder []string
 }
 
+// ZnException - exception 「异常」型, wraps a runtime error so that it
+// could be caught (捕获) and thrown (抛出) again inside Zn code.
+type ZnException struct {
+	*ZnObject
+	Err *error.Error
+}
+
+// ZnModule - module 「模块」型, an imported Zn program file. All top-level functions,
+// constants and classes of the file are exposed under the module's namespace.
+type ZnModule struct {
+	*ZnObject
+	Name  string
+	scope *RootScope
+}
+
 // KVPair - key-value pair, used for ZnHashMap
 type KVPair struct {
 	Key   string
@@ -101,18 +116,18 @@ func (zo *ZnObject) SetProperty(name string, value ZnValue) *error.Error {
 	return nil
 }
 
-// GetMethod -
+// GetMethod - find method along the class chain (the class itself first, then parent classes)
 func (zo *ZnObject) GetMethod(name string) (*ClosureRef, *error.Error) {
-	methodRef, ok := zo.MethodList[name]
+	methodRef, ok := zo.ClassRef.findMethod(name)
 	if !ok {
 		return nil, error.MethodNotFound(name)
 	}
 	return methodRef, nil
 }
 
-// FindGetter -
+// FindGetter - find getter along the class chain (the class itself first, then parent classes)
 func (zo *ZnObject) FindGetter(name string) (bool, *ClosureRef) {
-	getterRef, ok := zo.GetterList[name]
+	getterRef, ok := zo.ClassRef.findGetter(name)
 	if !ok {
 		return false, nil
 	}
@@ -158,10 +173,120 @@ func (zh *ZnHashMap) String() string {
 	return fmt.Sprintf("【%s】", strings.Join(strs, "，"))
 }
 
-// Rev - ZnBool
+// SetValue - set value of a key; if the key doesn't exist, append it to the end of KeyOrder.
+func (zh *ZnHashMap) SetValue(key string, value ZnValue) {
+	if _, ok := zh.Value[key]; !ok {
+		zh.KeyOrder = append(zh.KeyOrder, key)
+	}
+	zh.Value[key] = value
+}
+
+// DeleteValue - delete a key (and its value), returns false if the key doesn't exist.
+func (zh *ZnHashMap) DeleteValue(key string) bool {
+	if _, ok := zh.Value[key]; !ok {
+		return false
+	}
+	delete(zh.Value, key)
+	for idx, k := range zh.KeyOrder {
+		if k == key {
+			zh.KeyOrder = append(zh.KeyOrder[:idx], zh.KeyOrder[idx+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (ze *ZnException) String() string {
+	return fmt.Sprintf("‹%04X› %s：%s", ze.Err.GetCode(), ze.Err.GetErrorClassName(), ze.Err.Error())
+}
+
+func (zm *ZnModule) String() string {
+	return fmt.Sprintf("模块： %s", zm.Name)
+}
+
+// GetProperty - get exported function or constant of the module
+func (zm *ZnModule) GetProperty(name string) (ZnValue, *error.Error) {
+	if val, ok := zm.getExport(name); ok {
+		return val, nil
+	}
+	return nil, error.PropertyNotFound(name)
+}
+
+// SetProperty - module exports are read-only
+func (zm *ZnModule) SetProperty(name string, value ZnValue) *error.Error {
+	return error.AssignToConstant()
+}
+
+// GetMethod - get exported function of the module
+func (zm *ZnModule) GetMethod(name string) (*ClosureRef, *error.Error) {
+	if val, ok := zm.getExport(name); ok {
+		if fn, ok := val.(*ZnFunction); ok {
+			return fn.ClosureRef, nil
+		}
+	}
+	return nil, error.MethodNotFound(name)
+}
+
+// FindGetter - module has no getters
+func (zm *ZnModule) FindGetter(name string) (bool, *ClosureRef) {
+	return false, nil
+}
+
+// getExport - only functions & constants on the module's top level are exported
+func (zm *ZnModule) getExport(name string) (ZnValue, bool) {
+	sym, ok := zm.scope.GetSymbol(name)
+	if !ok {
+		return nil, false
+	}
+	if _, isFunc := sym.Value.(*ZnFunction); isFunc || sym.IsConstant {
+		return sym.Value, true
+	}
+	return nil, false
+}
+
+// Rev - get the reversed value of ZnBool (as a new value, the original one is not changed)
 func (zb *ZnBool) Rev() *ZnBool {
-	zb.Value = !zb.Value
-	return zb
+	return NewZnBool(!zb.Value)
+}
+
+// GetTypeName - get display name of the value's type (e.g. 文本，数值).
+// For objects, the name of its class is returned.
+func GetTypeName(value ZnValue) string {
+	switch v := value.(type) {
+	case *ZnString:
+		return "文本"
+	case *ZnDecimal:
+		return "数值"
+	case *ZnBool:
+		return "二象"
+	case *ZnArray:
+		return "元组"
+	case *ZnHashMap:
+		return "列表"
+	case *ZnNull:
+		return "空"
+	case *ZnFunction:
+		return "方法"
+	case *ZnException:
+		return "异常"
+	case *ZnModule:
+		return "模块"
+	case *ZnDate:
+		return "日期"
+	case *ZnTime:
+		return "时刻"
+	case *ZnDuration:
+		return "时长"
+	case *ZnMoney:
+		return "金额"
+	case *ZnFraction:
+		return "分数"
+	case *ZnObject:
+		if v.ClassRef != nil {
+			return v.ClassRef.Name
+		}
+	}
+	return "对象"
 }
 
 //////// New[Type] Constructors
@@ -169,7 +294,8 @@ func (zb *ZnBool) Rev() *ZnBool {
 // NewZnString -
 func NewZnString(value string) *ZnString {
 	return &ZnString{
-		Value: value,
+		Value:    value,
+		ZnObject: NewZnObject(defaultStringClassRef),
 	}
 }
 
@@ -194,17 +320,17 @@ func NewZnNull() *ZnNull {
 	return t
 }
 
-// NewZnFunction -
-func NewZnFunction(node *syntax.FunctionDeclareStmt) *ZnFunction {
+// NewZnFunction - create a function that captures the scope where it's defined
+func NewZnFunction(node *syntax.FunctionDeclareStmt, scope Scope) *ZnFunction {
 	funcName := node.FuncName.GetLiteral()
-	closureRef := NewClosureRef(funcName, node.ParamList, node.ExecBlock)
+	closureRef := NewClosureRef(funcName, node.ParamList, node.ExecBlock, scope)
 	return &ZnFunction{
 		ClosureRef: closureRef,
 	}
 }
 
 // NewZnNativeFunction - new Zn native function
-func NewZnNativeFunction(name string, executor funcExecutor) *ZnFunction {
+func NewZnNativeFunction(name string, executor FuncExecutor) *ZnFunction {
 	closureRef := NewNativeClosureRef(name, executor)
 	return &ZnFunction{
 		ClosureRef: closureRef,
@@ -216,16 +342,32 @@ func NewZnHashMap(kvPairs []KVPair) *ZnHashMap {
 	hm := &ZnHashMap{
 		Value:    map[string]ZnValue{},
 		KeyOrder: []string{},
+		ZnObject: NewZnObject(defaultHashMapClassRef),
 	}
 
 	for _, kvPair := range kvPairs {
-		hm.Value[kvPair.Key] = kvPair.Value
-		hm.KeyOrder = append(hm.KeyOrder, kvPair.Key)
+		hm.SetValue(kvPair.Key, kvPair.Value)
 	}
 
 	return hm
 }
 
+// NewZnException -
+func NewZnException(err *error.Error) *ZnException {
+	return &ZnException{
+		Err:      err,
+		ZnObject: NewZnObject(defaultExceptionClassRef),
+	}
+}
+
+// NewZnModule -
+func NewZnModule(name string, scope *RootScope) *ZnModule {
+	return &ZnModule{
+		Name:  name,
+		scope: scope,
+	}
+}
+
 // NewZnObject -
 func NewZnObject(classRef *ClassRef) *ZnObject {
 	return &ZnObject{
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/reg0007/Zn/error"
+	"github.com/reg0007/Zn/lex"
 )
 
 const (
@@ -82,6 +83,25 @@ func (zd *ZnDecimal) String() string {
 	return fmt.Sprintf("%s%s⏨%d", sflag, txt[0:1], pointPos-1)
 }
 
+// plainString - show full decimal string without sci format (e.g. 1200000000000000000000, 0.0000000123)
+func (zd *ZnDecimal) plainString() string {
+	var sflag = ""
+	if zd.co.Sign() < 0 {
+		sflag = "-"
+	}
+	var txt = new(big.Int).Abs(zd.co).String()
+
+	if zd.exp >= 0 {
+		return sflag + txt + strings.Repeat("0", zd.exp)
+	}
+	// add lead zeros, thus there's at least one digit before the decimal point
+	if len(txt) <= -zd.exp {
+		txt = strings.Repeat("0", -zd.exp-len(txt)+1) + txt
+	}
+	pointPos := len(txt) + zd.exp
+	return fmt.Sprintf("%s%s.%s", sflag, txt[:pointPos], txt[pointPos:])
+}
+
 // SetValue - set decimal value from raw string
 // raw string MUST be a valid number string
 func (zd *ZnDecimal) setValue(raw string) *error.Error {
@@ -89,7 +109,12 @@ func (zd *ZnDecimal) setValue(raw string) *error.Error {
 	var expValS = []rune{}
 	var dotNum = 0
 
-	var rawR = []rune(raw)
+	// transform Chinese numerals & suffixed numbers (e.g. 三千五百，1.2万，15%) to ASCII form
+	normalized, ok := lex.NormalizeNumber([]rune(raw))
+	if !ok {
+		return error.ParseFromStringError(raw)
+	}
+	var rawR = []rune(normalized)
 	// similar with the regex parser in lexer.go
 	const (
 		sBegin  = 1
@@ -186,9 +211,19 @@ func (zd *ZnDecimal) asInteger() (int, *error.Error) {
 		raw := zd.String()
 		return 0, error.ToIntegerError(raw)
 	}
-	if !zd.co.IsInt64() {
+	value := zd.co
+	// e.g. 1万 (co = 1, exp = 4) -> 10000; a non-zero number with more than 18 zeros
+	// always overflows int64, thus huge exponents like 1E+2000000000 are never computed
+	if zd.exp > 0 && zd.co.Sign() != 0 {
+		if zd.exp > 18 {
+			return 0, error.ToIntegerError(zd.String())
+		}
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(zd.exp)), nil)
+		value = new(big.Int).Mul(zd.co, factor)
+	}
+	if !value.IsInt64() {
 		raw := zd.String()
 		return 0, error.ToIntegerError(raw)
 	}
-	return int(zd.co.Int64()), nil
+	return int(value.Int64()), nil
 }
@@ -84,6 +84,36 @@ func TestDecimal_ParseValue(t *testing.T) {
 			input: ".56e+9",
 			value: "(56, 7)",
 		},
+		{
+			name:  "chinese numeral",
+			input: "三千五百",
+			value: "(3500, 0)",
+		},
+		{
+			name:  "chinese numeral with dot",
+			input: "负三点一四",
+			value: "(-314, -2)",
+		},
+		{
+			name:  "magnitude suffix",
+			input: "1.2万",
+			value: "(12, 3)",
+		},
+		{
+			name:  "chinese percentage",
+			input: "百分之十五",
+			value: "(15, -2)",
+		},
+		{
+			name:  "percent mark",
+			input: "2.5%",
+			value: "(25, -3)",
+		},
+		{
+			name:        "invalid chinese numeral",
+			input:       "一二三",
+			expectError: true,
+		},
 	}
 
 	for _, tt := range cases {
@@ -105,6 +135,71 @@ func TestDecimal_ParseValue(t *testing.T) {
 	}
 }
 
+func TestDecimal_DivNegative(t *testing.T) {
+	cases := []struct {
+		a      int
+		b      int
+		expect string
+	}{
+		{5, 4, "1.2500000"},
+		{-5, 4, "-1.2500000"},
+		{5, -4, "-1.2500000"},
+		{-5, -4, "1.2500000"},
+		{-1, 3, "-0.33333333"},
+	}
+
+	arith := NewArith(8)
+	for _, tt := range cases {
+		a, b := NewZnDecimalFromInt(tt.a, 0), NewZnDecimalFromInt(tt.b, 0)
+		got, err := arith.Div(a, b)
+		if err != nil {
+			t.Errorf("Div(%d, %d) expect no error, got %s", tt.a, tt.b, err.Error())
+			continue
+		}
+		if got.String() != tt.expect {
+			t.Errorf("Div(%d, %d) expect -> %s, got -> %s", tt.a, tt.b, tt.expect, got.String())
+		}
+		// operands should not be changed
+		if a.String() != fmt.Sprint(tt.a) || b.String() != fmt.Sprint(tt.b) {
+			t.Errorf("Div() should not change the operands, got %s, %s", a.String(), b.String())
+		}
+	}
+}
+
+func TestDecimal_AsInteger(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect int
+		expErr bool
+	}{
+		{"12", 12, false},
+		{"1万", 10000, false},
+		{"-1.2E+3", -1200, false},
+		{"0E+30", 0, false},
+		{"1.5", 0, true},
+		{"1E+30", 0, true},
+		{"1E+2000000000", 0, true},
+	}
+
+	for _, tt := range cases {
+		zd, _ := NewZnDecimal(tt.input)
+		got, err := zd.asInteger()
+		if tt.expErr {
+			if err == nil {
+				t.Errorf("asInteger(%s) expect error, got %d", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("asInteger(%s) expect no error, got %s", tt.input, err.Error())
+			continue
+		}
+		if got != tt.expect {
+			t.Errorf("asInteger(%s) expect -> %d, got -> %d", tt.input, tt.expect, got)
+		}
+	}
+}
+
 func stringify(zd *ZnDecimal) string {
 	return fmt.Sprintf("(%s, %d)", zd.co.String(), zd.exp)
 }
@@ -0,0 +1,118 @@
+package exec
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/reg0007/Zn/error"
+)
+
+// ZnFraction - fraction 「分数」型, an exact rational number (e.g. 1/3) that is never
+// truncated by the precision of divisions.
+type ZnFraction struct {
+	*ZnObject
+	Value *big.Rat
+}
+
+// NewZnFraction -
+func NewZnFraction(value *big.Rat) *ZnFraction {
+	return &ZnFraction{
+		Value:    value,
+		ZnObject: NewZnObject(defaultFractionClassRef),
+	}
+}
+
+// String - e.g. 1/3，-5/2; integers are displayed without denominator, e.g. 3
+func (zf *ZnFraction) String() string {
+	return zf.Value.RatString()
+}
+
+// fractionRegex - e.g. 1/3，-5/2，3，1.5/2
+var fractionRegex = regexp.MustCompile(`^([-+]?[0-9]+(?:\.[0-9]+)?)(?:\s*/\s*([-+]?[0-9]+(?:\.[0-9]+)?))?$`)
+
+// ParseZnFraction - parse fraction from string, e.g. 「1/3」，「-5/2」，「3」
+func ParseZnFraction(raw string) (*ZnFraction, *error.Error) {
+	matches := fractionRegex.FindStringSubmatch(strings.TrimSpace(raw))
+	if matches == nil {
+		return nil, error.ParseFromStringError(raw)
+	}
+	num, _ := new(big.Rat).SetString(matches[1])
+	if matches[2] == "" {
+		return NewZnFraction(num), nil
+	}
+	den, _ := new(big.Rat).SetString(matches[2])
+	if den.Sign() == 0 {
+		return nil, error.ArithDivZeroError()
+	}
+	return NewZnFraction(num.Quo(num, den)), nil
+}
+
+// ratFromDecimal - convert decimal to rational number exactly, e.g. 1.25 -> 5/4
+func ratFromDecimal(zd *ZnDecimal) (*big.Rat, *error.Error) {
+	if zd.co.Sign() == 0 {
+		return new(big.Rat), nil
+	}
+	factor, err := pow10(absInt(zd.exp))
+	if err != nil {
+		return nil, err
+	}
+	if zd.exp >= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(zd.co, factor)), nil
+	}
+	return new(big.Rat).SetFrac(zd.co, factor), nil
+}
+
+// quoDecimals - divide decimals exactly, e.g. quoDecimals(1, 3) = 1/3
+func quoDecimals(a *ZnDecimal, b *ZnDecimal) (*big.Rat, *error.Error) {
+	if b.co.Sign() == 0 {
+		return nil, error.ArithDivZeroError()
+	}
+	ra, err := ratFromDecimal(a)
+	if err != nil {
+		return nil, err
+	}
+	rb, err := ratFromDecimal(b)
+	if err != nil {
+		return nil, err
+	}
+	return ra.Quo(ra, rb), nil
+}
+
+// newDecimalFromBigInt - e.g. 分数之分子
+func newDecimalFromBigInt(value *big.Int) *ZnDecimal {
+	result := NewZnDecimalFromInt(0, 0)
+	result.co = new(big.Int).Set(value)
+	return result
+}
+
+// roundRat - convert rational number to decimal, rounded to N places by given mode,
+// e.g. roundRat(2/3, 2, RoundHalfUp) = 0.67
+func roundRat(r *big.Rat, places int, mode RoundingMode) (*ZnDecimal, *error.Error) {
+	if err := checkPlaces(places); err != nil {
+		return nil, err
+	}
+	factor, _ := pow10(absInt(places))
+	num := new(big.Int).Set(r.Num())
+	den := new(big.Int).Set(r.Denom())
+	if places >= 0 {
+		num.Mul(num, factor)
+	} else {
+		den.Mul(den, factor)
+	}
+
+	rem := new(big.Int)
+	quo, rem := new(big.Int).QuoRem(num, den, rem)
+	if rem.Sign() != 0 {
+		// compare the dropped part with half of the last place
+		cmpHalf := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(den)
+		sign := r.Sign()
+		if shouldRoundAway(mode, sign, cmpHalf, quo.Bit(0) == 1) {
+			quo.Add(quo, big.NewInt(int64(sign)))
+		}
+	}
+	result := newDecimalFromBigInt(quo)
+	result.exp = -places
+	normalizeZero(result)
+	return result, nil
+}
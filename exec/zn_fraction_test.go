@@ -0,0 +1,104 @@
+package exec
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseZnFraction(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect string
+		errStr string
+	}{
+		{"1/3", "1/3", ""},
+		{"2/6", "1/3", ""},
+		{" -5 / 2 ", "-5/2", ""},
+		{"4/2", "2", ""},
+		{"1.5/2", "3/4", ""},
+		{"7", "7", ""},
+		{"1/0", "", "被除数不得为0"},
+		{"1/3/4", "", "解析「1/3/4」错误"},
+		{"三分之一", "", "解析「三分之一」错误"},
+	}
+
+	for _, tt := range cases {
+		f, err := ParseZnFraction(tt.input)
+		if tt.errStr != "" {
+			if err == nil || err.Error() != tt.errStr {
+				t.Errorf("ParseZnFraction(%s) expect error -> %s, got -> %v", tt.input, tt.errStr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseZnFraction(%s) expect no error, got -> %s", tt.input, err.Error())
+			continue
+		}
+		if f.String() != tt.expect {
+			t.Errorf("ParseZnFraction(%s) expect -> %s, got -> %s", tt.input, tt.expect, f.String())
+		}
+	}
+}
+
+func TestRatFromDecimal(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect string
+	}{
+		{"1.25", "5/4"},
+		{"-0.5", "-1/2"},
+		{"1.2E3", "1200"},
+		{"0", "0"},
+	}
+
+	for _, tt := range cases {
+		got, err := ratFromDecimal(newDecimal(tt.input))
+		if err != nil {
+			t.Errorf("ratFromDecimal(%s) expect no error, got %s", tt.input, err.Error())
+			continue
+		}
+		if got.RatString() != tt.expect {
+			t.Errorf("ratFromDecimal(%s) expect -> %s, got -> %s", tt.input, tt.expect, got.RatString())
+		}
+	}
+
+	if _, err := ratFromDecimal(newDecimal("1E-100000000")); err == nil || err.GetCode() != 0x2609 {
+		t.Errorf("ratFromDecimal(1E-100000000) should return error 2609, got %v", err)
+	}
+}
+
+func TestRoundRat(t *testing.T) {
+	cases := []struct {
+		input  *big.Rat
+		places int
+		mode   RoundingMode
+		expect string
+	}{
+		{big.NewRat(2, 3), 2, RoundHalfUp, "0.67"},
+		{big.NewRat(2, 3), 2, RoundTruncate, "0.66"},
+		{big.NewRat(-2, 3), 2, RoundFloor, "-0.67"},
+		{big.NewRat(-2, 3), 2, RoundCeiling, "-0.66"},
+		{big.NewRat(5, 2), 0, RoundHalfEven, "2"},
+		{big.NewRat(7, 2), 0, RoundHalfEven, "4"},
+		{big.NewRat(-5, 2), 0, RoundHalfUp, "-3"},
+		{big.NewRat(1, 4), 4, RoundHalfUp, "0.2500"},
+		{big.NewRat(1250, 1), -2, RoundHalfUp, "1300"},
+		{big.NewRat(1, 3), -2, RoundHalfUp, "0"},
+	}
+
+	for _, tt := range cases {
+		got, err := roundRat(tt.input, tt.places, tt.mode)
+		if err != nil {
+			t.Errorf("roundRat(%s, %d) expect no error, got %s", tt.input.RatString(), tt.places, err.Error())
+			continue
+		}
+		if got.String() != tt.expect {
+			t.Errorf("roundRat(%s, %d, %s) expect -> %s, got -> %s",
+				tt.input.RatString(), tt.places, getRoundingModeName(tt.mode), tt.expect, got.String())
+		}
+	}
+
+	if _, err := roundRat(big.NewRat(1, 3), 100000000000, RoundHalfUp); err == nil || err.GetCode() != 0x260A {
+		t.Errorf("roundRat(1/3, 100000000000) should return error 260A, got %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package exec
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/reg0007/Zn/error"
+	"github.com/reg0007/Zn/lex"
+)
+
+// Currency - the definition of a currency, e.g. CNY (人民币)
+type Currency struct {
+	// Code - ISO 4217 code, e.g. CNY
+	Code string
+	// Name - e.g. 人民币，美元
+	Name string
+	// Symbol - the prefix for display (e.g. ¥); if empty, Unit is displayed as the suffix instead
+	Symbol string
+	// Unit - e.g. 元，美元
+	Unit string
+	// MinorUnits - number of decimal places of the minor unit, e.g. 2 for 分, 0 for JPY
+	MinorUnits int
+}
+
+// Currencies - all supported currencies, indexed by code
+var Currencies = map[string]*Currency{
+	"CNY": {"CNY", "人民币", "¥", "元", 2},
+	"USD": {"USD", "美元", "", "美元", 2},
+	"EUR": {"EUR", "欧元", "", "欧元", 2},
+	"JPY": {"JPY", "日元", "", "日元", 0},
+	"HKD": {"HKD", "港元", "", "港元", 2},
+	"GBP": {"GBP", "英镑", "", "英镑", 2},
+}
+
+// ZnMoney - money 「金额」型, an amount with its currency, e.g. ¥12.50，20美元
+type ZnMoney struct {
+	*ZnObject
+	Currency *Currency
+	// Amount - the exact amount, which is NOT rounded to minor units automatically
+	Amount *ZnDecimal
+}
+
+// NewZnMoney -
+func NewZnMoney(amount *ZnDecimal, currency *Currency) *ZnMoney {
+	return &ZnMoney{
+		Currency: currency,
+		Amount:   amount,
+		ZnObject: NewZnObject(defaultMoneyClassRef),
+	}
+}
+
+// String - the amount is displayed with (at least) the places of minor units, e.g. ¥12.50，-¥0.125，20.00美元
+func (zm *ZnMoney) String() string {
+	amount := zm.Amount
+	var txt string
+	if checkFormatDigits(amount) != nil {
+		// amounts with huge exponents are displayed in scientific notation, e.g. ¥1⏨2000000000
+		txt = amount.String()
+	} else {
+		if places := zm.Currency.MinorUnits; amount.exp > -places {
+			amount = copyZnDecimal(amount)
+			factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(amount.exp+places)), nil)
+			amount.co.Mul(amount.co, factor)
+			amount.exp = -places
+		}
+		txt = amount.plainString()
+	}
+	sign := ""
+	if strings.HasPrefix(txt, "-") {
+		sign, txt = "-", txt[1:]
+	}
+	if zm.Currency.Symbol != "" {
+		return sign + zm.Currency.Symbol + txt
+	}
+	return sign + txt + zm.Currency.Unit
+}
+
+// GetCurrency - get currency from its code (e.g. CNY, usd), name (e.g. 人民币) or unit (e.g. 元)
+func GetCurrency(name string) (*Currency, *error.Error) {
+	if c, ok := Currencies[strings.ToUpper(name)]; ok {
+		return c, nil
+	}
+	for _, c := range Currencies {
+		if name == c.Name || name == c.Unit || (c.Symbol != "" && name == c.Symbol) {
+			return c, nil
+		}
+	}
+	return nil, error.InvalidCurrency(name)
+}
+
+// newMoneyFromLiteral - e.g. ¥12.50，三千五百元 (see lex.SplitMoneyLiteral)
+func newMoneyFromLiteral(literal string) (*ZnMoney, bool, *error.Error) {
+	number, code, ok := lex.SplitMoneyLiteral([]rune(literal))
+	if !ok {
+		return nil, false, nil
+	}
+	amount, err := NewZnDecimal(string(number))
+	if err != nil {
+		return nil, true, err
+	}
+	currency, err := GetCurrency(code)
+	if err != nil {
+		return nil, true, err
+	}
+	return NewZnMoney(amount, currency), true, nil
+}
+
+// roundMoney - round the amount to minor units by given mode, e.g. ¥12.345 -> ¥12.35
+func roundMoney(arith *Arith, zm *ZnMoney, mode RoundingMode) (*ZnMoney, *error.Error) {
+	amount, err := arith.Round(zm.Amount, zm.Currency.MinorUnits, mode)
+	if err != nil {
+		return nil, err
+	}
+	return NewZnMoney(amount, zm.Currency), nil
+}
+
+// maxAllocateParts - the max number of parts that a money could be divided into (by 平分)
+const maxAllocateParts = 10000
+
+// allocateMoney - allocate the money into parts by ratios, e.g. ¥100 by 1:1:1 -> ¥33.34, ¥33.33, ¥33.33
+//
+// The amount is rounded to minor units by given mode first, then distributed by the largest
+// remainder method: each part gets floor(total × ratio ÷ sum of ratios) minor units at first,
+// and the rest minor units are given one by one to the parts with largest remainders (the earlier
+// part first on ties). Thus the sum of all parts is always exactly the (rounded) amount.
+func allocateMoney(arith *Arith, zm *ZnMoney, ratios []*ZnDecimal, mode RoundingMode) ([]*ZnMoney, *error.Error) {
+	if len(ratios) == 0 {
+		return nil, error.LeastParamsError(1)
+	}
+	// rescale ratios to integer weights, e.g. 0.5:1.25 -> 50:125
+	minExp := ratios[0].exp
+	for _, ratio := range ratios {
+		if ratio.co.Sign() < 0 {
+			return nil, error.InvalidRatio(ratio.String())
+		}
+		if ratio.exp < minExp {
+			minExp = ratio.exp
+		}
+	}
+	weights := []*big.Int{}
+	sum := new(big.Int)
+	for _, ratio := range ratios {
+		factor, err := pow10(ratio.exp - minExp)
+		if err != nil {
+			return nil, err
+		}
+		w := new(big.Int).Mul(ratio.co, factor)
+		weights = append(weights, w)
+		sum.Add(sum, w)
+	}
+	if sum.Sign() == 0 {
+		return nil, error.InvalidRatio("0")
+	}
+
+	places := zm.Currency.MinorUnits
+	rounded, err := arith.Round(zm.Amount, places, mode)
+	if err != nil {
+		return nil, err
+	}
+	total := new(big.Int).Abs(rounded.co)
+
+	shares := make([]*big.Int, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	rest := new(big.Int).Set(total)
+	for idx, w := range weights {
+		shares[idx], remainders[idx] = new(big.Int).QuoRem(new(big.Int).Mul(total, w), sum, new(big.Int))
+		rest.Sub(rest, shares[idx])
+	}
+	// rest < len(weights), since each part loses less than 1 minor unit
+	order := make([]int, len(weights))
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+	})
+	for k := 0; k < int(rest.Int64()); k++ {
+		shares[order[k]].Add(shares[order[k]], big.NewInt(1))
+	}
+
+	result := []*ZnMoney{}
+	for _, share := range shares {
+		if rounded.co.Sign() < 0 {
+			share.Neg(share)
+		}
+		amount := NewZnDecimalFromInt(0, -places)
+		amount.co = share
+		result = append(result, NewZnMoney(amount, zm.Currency))
+	}
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package exec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestZnMoney_String(t *testing.T) {
+	cases := []struct {
+		amount   string
+		currency string
+		expect   string
+	}{
+		{"12.5", "CNY", "¥12.50"},
+		{"-3", "CNY", "-¥3.00"},
+		{"0.125", "CNY", "¥0.125"},
+		{"20", "USD", "20.00美元"},
+		{"1000", "JPY", "1000日元"},
+		{"1.2E4", "EUR", "12000.00欧元"},
+		{"1E+2000000000", "CNY", "¥1⏨2000000000"},
+		{"-1.5E-2000000000", "USD", "-1.5⏨-2000000000美元"},
+	}
+
+	for _, tt := range cases {
+		amount, _ := NewZnDecimal(tt.amount)
+		if got := NewZnMoney(amount, Currencies[tt.currency]).String(); got != tt.expect {
+			t.Errorf("String() of %s %s expect -> %s, got -> %s", tt.amount, tt.currency, tt.expect, got)
+		}
+	}
+}
+
+func TestGetCurrency(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect string
+		errStr string
+	}{
+		{"CNY", "CNY", ""},
+		{"usd", "USD", ""},
+		{"人民币", "CNY", ""},
+		{"元", "CNY", ""},
+		{"¥", "CNY", ""},
+		{"日元", "JPY", ""},
+		{"XYZ", "", "币种「XYZ」无效"},
+	}
+
+	for _, tt := range cases {
+		c, err := GetCurrency(tt.input)
+		if tt.errStr != "" {
+			if err == nil || err.Error() != tt.errStr {
+				t.Errorf("GetCurrency(%s) expect error -> %s, got -> %v", tt.input, tt.errStr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("GetCurrency(%s) expect no error, got -> %s", tt.input, err.Error())
+			continue
+		}
+		if c.Code != tt.expect {
+			t.Errorf("GetCurrency(%s) expect -> %s, got -> %s", tt.input, tt.expect, c.Code)
+		}
+	}
+}
+
+func TestAllocateMoney(t *testing.T) {
+	cases := []struct {
+		name     string
+		amount   string
+		currency string
+		ratios   []string
+		mode     RoundingMode
+		expect   string
+		errStr   string
+	}{
+		{"equal parts", "100", "CNY", []string{"1", "1", "1"}, RoundHalfUp, "¥33.34 ¥33.33 ¥33.33", ""},
+		{"by ratios", "100", "CNY", []string{"3", "2", "1"}, RoundHalfUp, "¥50.00 ¥33.33 ¥16.67", ""},
+		{"decimal ratios", "10", "CNY", []string{"0.5", "0.25", "0.25"}, RoundHalfUp, "¥5.00 ¥2.50 ¥2.50", ""},
+		{"largest remainder first", "0.05", "CNY", []string{"1", "2", "2"}, RoundHalfUp, "¥0.01 ¥0.02 ¥0.02", ""},
+		{"zero ratio", "10", "CNY", []string{"1", "0", "2"}, RoundHalfUp, "¥3.33 ¥0.00 ¥6.67", ""},
+		{"negative amount", "-100", "CNY", []string{"1", "1", "1"}, RoundHalfUp, "-¥33.34 -¥33.33 -¥33.33", ""},
+		{"round amount first", "10.005", "CNY", []string{"1", "1"}, RoundHalfEven, "¥5.00 ¥5.00", ""},
+		{"no minor units", "1000", "JPY", []string{"1", "1", "1"}, RoundHalfUp, "334日元 333日元 333日元", ""},
+		{"negative ratio", "10", "CNY", []string{"1", "-1"}, RoundHalfUp, "", "分配比例「-1」无效：比例不能为负数，且不能全为0"},
+		{"all zero ratios", "10", "CNY", []string{"0", "0"}, RoundHalfUp, "", "分配比例「0」无效：比例不能为负数，且不能全为0"},
+	}
+
+	arith := NewArith(defaultPrecision)
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, _ := NewZnDecimal(tt.amount)
+			ratios := []*ZnDecimal{}
+			for _, r := range tt.ratios {
+				ratio, _ := NewZnDecimal(r)
+				ratios = append(ratios, ratio)
+			}
+			parts, err := allocateMoney(arith, NewZnMoney(amount, Currencies[tt.currency]), ratios, tt.mode)
+			if tt.errStr != "" {
+				if err == nil || err.Error() != tt.errStr {
+					t.Errorf("expect error -> %s, got -> %v", tt.errStr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expect no error, got -> %s", err.Error())
+				return
+			}
+			strs := []string{}
+			for _, part := range parts {
+				strs = append(strs, part.String())
+			}
+			if got := strings.Join(strs, " "); got != tt.expect {
+				t.Errorf("expect -> %s, got -> %s", tt.expect, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,443 @@
+package exec
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reg0007/Zn/error"
+)
+
+// ZnDate - date 「日期」型, a calendar day without time & timezone, e.g. 2024年3月5日
+type ZnDate struct {
+	*ZnObject
+	// always be 00:00:00 UTC of the day
+	Value time.Time
+}
+
+// ZnTime - time 「时刻」型, an instant of time with its timezone, e.g. 2024年3月5日 14:30:00
+type ZnTime struct {
+	*ZnObject
+	Value time.Time
+}
+
+// ZnDuration - duration 「时长」型, e.g. 3天4小时
+type ZnDuration struct {
+	*ZnObject
+	Value time.Duration
+}
+
+// NewZnDate - new date from year, month & day. Overflowed values will be normalized
+// (e.g. 2024-02-30 -> 2024-03-01); use ParseZnDate() to validate user inputs.
+func NewZnDate(year int, month int, day int) *ZnDate {
+	return &ZnDate{
+		Value:    time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC),
+		ZnObject: NewZnObject(defaultDateClassRef),
+	}
+}
+
+// NewZnTime -
+func NewZnTime(value time.Time) *ZnTime {
+	return &ZnTime{
+		Value:    value,
+		ZnObject: NewZnObject(defaultTimeClassRef),
+	}
+}
+
+// NewZnDuration -
+func NewZnDuration(value time.Duration) *ZnDuration {
+	return &ZnDuration{
+		Value:    value,
+		ZnObject: NewZnObject(defaultDurationClassRef),
+	}
+}
+
+// String - e.g. 2024年3月5日
+func (zd *ZnDate) String() string {
+	return formatTime(zd.Value, "YYYY年M月D日")
+}
+
+// String - e.g. 2024年3月5日 14:30:00
+func (zt *ZnTime) String() string {
+	return formatTime(zt.Value, "YYYY年M月D日 HH:mm:ss")
+}
+
+// String - e.g. 3天4小时5分钟6秒, 500毫秒
+func (zd *ZnDuration) String() string {
+	d := zd.Value
+	if d == 0 {
+		return "0秒"
+	}
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteString("负")
+		d = -d
+	}
+	for _, unit := range durationDisplayUnits {
+		if d >= unit.value {
+			sb.WriteString(fmt.Sprintf("%d%s", d/unit.value, unit.name))
+			d = d % unit.value
+		}
+	}
+	return sb.String()
+}
+
+//// parse & format
+
+var durationDisplayUnits = []struct {
+	name  string
+	value time.Duration
+}{
+	{"天", 24 * time.Hour},
+	{"小时", time.Hour},
+	{"分钟", time.Minute},
+	{"秒", time.Second},
+	{"毫秒", time.Millisecond},
+}
+
+// DurationUnits - all valid units of a duration, e.g. （时长：3，「天」）
+var DurationUnits = map[string]time.Duration{
+	"周":  7 * 24 * time.Hour,
+	"星期": 7 * 24 * time.Hour,
+	"天":  24 * time.Hour,
+	"日":  24 * time.Hour,
+	"小时": time.Hour,
+	"时":  time.Hour,
+	"分钟": time.Minute,
+	"分":  time.Minute,
+	"秒":  time.Second,
+	"毫秒": time.Millisecond,
+}
+
+var (
+	// e.g. 2024年3月5日，2024-03-05，2024/3/5
+	dateRegex = regexp.MustCompile(`^\s*(\d{4})\s*[年\-/.]\s*(\d{1,2})\s*[月\-/.]\s*(\d{1,2})\s*日?`)
+	// e.g. 14:30，14:30:05，14时30分5秒，14点30分
+	clockRegex = regexp.MustCompile(`^\s*(\d{1,2})\s*[:：时点]\s*(\d{1,2})\s*(?:[:：分]\s*(?:(\d{1,2})\s*秒?)?)?\s*$`)
+	// e.g. 3天4小时，1.5小时
+	durationRegex = regexp.MustCompile(`\s*(\d+(?:\.\d+)?)\s*(毫秒|秒|分钟|分|小时|时|天|日|星期|周)`)
+)
+
+// newDateTime - compose time from fields; returns false if any of them is out of range (e.g. 2月30日)
+func newDateTime(fields [6]int, loc *time.Location) (time.Time, bool) {
+	year, month, day, hour, minute, second := fields[0], fields[1], fields[2], fields[3], fields[4], fields[5]
+	t := time.Date(year, time.Month(month), day, hour, minute, second, 0, loc)
+	if t.Year() != year || int(t.Month()) != month || t.Day() != day ||
+		t.Hour() != hour || t.Minute() != minute || t.Second() != second {
+		return t, false
+	}
+	return t, true
+}
+
+// parseDateTimeFields - parse date (and optional clock) string to [year, month, day, hour, minute, second]
+func parseDateTimeFields(raw string, allowClock bool) ([6]int, bool) {
+	var fields [6]int
+	match := dateRegex.FindStringSubmatch(raw)
+	if match == nil {
+		return fields, false
+	}
+	for i := 0; i < 3; i++ {
+		fields[i], _ = strconv.Atoi(match[i+1])
+	}
+
+	rest := raw[len(match[0]):]
+	if strings.TrimSpace(rest) == "" {
+		return fields, true
+	}
+	if !allowClock {
+		return fields, false
+	}
+	clock := clockRegex.FindStringSubmatch(rest)
+	if clock == nil {
+		return fields, false
+	}
+	for i := 0; i < 3; i++ {
+		if clock[i+1] != "" {
+			fields[i+3], _ = strconv.Atoi(clock[i+1])
+		}
+	}
+	return fields, true
+}
+
+// ParseZnDate - parse date from string, e.g. 2024年3月5日，2024-03-05
+func ParseZnDate(raw string) (*ZnDate, *error.Error) {
+	fields, ok := parseDateTimeFields(raw, false)
+	if !ok {
+		return nil, error.InvalidDateTime(raw)
+	}
+	if _, ok := newDateTime(fields, time.UTC); !ok {
+		return nil, error.InvalidDateTime(raw)
+	}
+	return NewZnDate(fields[0], fields[1], fields[2]), nil
+}
+
+// ParseZnTime - parse time from string under the timezone, e.g. 2024年3月5日 14:30，2024-03-05 14:30:05
+func ParseZnTime(raw string, loc *time.Location) (*ZnTime, *error.Error) {
+	fields, ok := parseDateTimeFields(raw, true)
+	if !ok {
+		return nil, error.InvalidDateTime(raw)
+	}
+	t, ok := newDateTime(fields, loc)
+	if !ok {
+		return nil, error.InvalidDateTime(raw)
+	}
+	return NewZnTime(t), nil
+}
+
+// ParseZnDuration - parse duration from string, e.g. 3天4小时，1.5小时，90分钟
+func ParseZnDuration(raw string) (*ZnDuration, *error.Error) {
+	txt := strings.TrimSpace(raw)
+	sign := int64(1)
+	if strings.HasPrefix(txt, "负") {
+		sign = -1
+		txt = strings.TrimPrefix(txt, "负")
+	}
+
+	matches := durationRegex.FindAllStringSubmatchIndex(txt, -1)
+	if len(matches) == 0 {
+		return nil, error.InvalidDuration(raw)
+	}
+	total := new(big.Rat)
+	lastIdx := 0
+	for _, m := range matches {
+		// all chars must be matched
+		if m[0] != lastIdx {
+			return nil, error.InvalidDuration(raw)
+		}
+		lastIdx = m[1]
+
+		num, _ := new(big.Rat).SetString(txt[m[2]:m[3]])
+		unit := DurationUnits[txt[m[4]:m[5]]]
+		total.Add(total, num.Mul(num, new(big.Rat).SetInt64(int64(unit))))
+	}
+	if strings.TrimSpace(txt[lastIdx:]) != "" {
+		return nil, error.InvalidDuration(raw)
+	}
+	ns := new(big.Int).Quo(total.Num(), total.Denom())
+	if !ns.IsInt64() {
+		return nil, error.DurationOutOfRange(raw)
+	}
+	return NewZnDuration(time.Duration(sign * ns.Int64())), nil
+}
+
+// newDurationFromDecimal - e.g. (1.5, 「小时」) -> 1小时30分钟
+func newDurationFromDecimal(num *ZnDecimal, unitName string) (*ZnDuration, *error.Error) {
+	unit, ok := DurationUnits[unitName]
+	if !ok {
+		return nil, error.InvalidDuration(num.String() + unitName)
+	}
+	// check the magnitude first to avoid expanding huge exponents, since
+	// 1E19纳秒 is out of range already, while 1E-30周 is less than 1纳秒.
+	digits := len(new(big.Int).Abs(num.co).String())
+	if num.co.Sign() != 0 && digits+num.exp > 19 {
+		return nil, error.DurationOutOfRange(num.String() + unitName)
+	}
+	if num.co.Sign() == 0 || digits+num.exp < -30 {
+		return NewZnDuration(0), nil
+	}
+	r := new(big.Rat).SetInt(num.co)
+	factor := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(absInt(num.exp))), nil))
+	if num.exp >= 0 {
+		r.Mul(r, factor)
+	} else {
+		r.Quo(r, factor)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(int64(unit)))
+	ns := new(big.Int).Quo(r.Num(), r.Denom())
+	if !ns.IsInt64() {
+		return nil, error.DurationOutOfRange(num.String() + unitName)
+	}
+	return NewZnDuration(time.Duration(ns.Int64())), nil
+}
+
+// addDurations - a + b; ok is false if the result overflows
+func addDurations(a time.Duration, b time.Duration) (time.Duration, bool) {
+	sum := a + b
+	return sum, (b >= 0) == (sum >= a)
+}
+
+// subDurations - a - b; ok is false if the result overflows
+func subDurations(a time.Duration, b time.Duration) (time.Duration, bool) {
+	diff := a - b
+	return diff, (b >= 0) == (diff <= a)
+}
+
+// GetTimeZone - get timezone from its name. Besides IANA names (e.g. Asia/Shanghai),
+// fixed offsets (e.g. UTC+8, UTC-05:30) and 北京时间 are also supported.
+func GetTimeZone(name string) (*time.Location, *error.Error) {
+	switch name {
+	case "北京时间":
+		return time.FixedZone("UTC+8", 8*3600), nil
+	case "UTC", "GMT":
+		return time.UTC, nil
+	}
+	if strings.HasPrefix(name, "UTC") && len(name) > 3 {
+		sign := 1
+		switch name[3] {
+		case '+':
+		case '-':
+			sign = -1
+		default:
+			return nil, error.InvalidTimeZone(name)
+		}
+		parts := strings.SplitN(name[4:], ":", 2)
+		hours, err := strconv.Atoi(parts[0])
+		if err != nil || hours < 0 || hours > 14 {
+			return nil, error.InvalidTimeZone(name)
+		}
+		minutes := 0
+		if len(parts) == 2 {
+			if minutes, err = strconv.Atoi(parts[1]); err != nil || minutes >= 60 {
+				return nil, error.InvalidTimeZone(name)
+			}
+		}
+		return time.FixedZone(name, sign*(hours*3600+minutes*60)), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "" || name == "Local" {
+		return nil, error.InvalidTimeZone(name)
+	}
+	return loc, nil
+}
+
+var weekdayNames = []string{"日", "一", "二", "三", "四", "五", "六"}
+
+// formatTime - format time by the template, supported placeholders are:
+//
+// YYYY - year, MM/M - month, DD/D - day, HH/H - hour, mm - minute, ss - second, W - weekday (一 ~ 日)
+//
+// e.g. YYYY年M月D日 -> 2024年3月5日, YYYY-MM-DD HH:mm -> 2024-03-05 14:30
+func formatTime(t time.Time, template string) string {
+	placeholders := []struct {
+		name  string
+		value func() string
+	}{
+		{"YYYY", func() string { return fmt.Sprintf("%04d", t.Year()) }},
+		{"MM", func() string { return fmt.Sprintf("%02d", int(t.Month())) }},
+		{"M", func() string { return strconv.Itoa(int(t.Month())) }},
+		{"DD", func() string { return fmt.Sprintf("%02d", t.Day()) }},
+		{"D", func() string { return strconv.Itoa(t.Day()) }},
+		{"HH", func() string { return fmt.Sprintf("%02d", t.Hour()) }},
+		{"H", func() string { return strconv.Itoa(t.Hour()) }},
+		{"mm", func() string { return fmt.Sprintf("%02d", t.Minute()) }},
+		{"ss", func() string { return fmt.Sprintf("%02d", t.Second()) }},
+		{"W", func() string { return weekdayNames[t.Weekday()] }},
+	}
+
+	var sb strings.Builder
+	for idx := 0; idx < len(template); {
+		matched := false
+		for _, p := range placeholders {
+			if strings.HasPrefix(template[idx:], p.name) {
+				sb.WriteString(p.value())
+				idx += len(p.name)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			sb.WriteByte(template[idx])
+			idx++
+		}
+	}
+	return sb.String()
+}
+
+//// date & time calculation
+
+// addMonths - add months to a date; if the day overflows, it will be the last day
+// of the month instead, e.g. 1月31日 + 1个月 -> 2月29日 (of leap year)
+func addMonths(t time.Time, months int) time.Time {
+	firstDay := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := firstDay.AddDate(0, months, 0)
+	lastDay := target.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return target.AddDate(0, 0, day-1)
+}
+
+// maxDateOffset - the max number of days (or months, years, workdays) that could be added
+// to a date at once, about 10000 years
+const maxDateOffset = 3660000
+
+// addWorkdays - add working days (Monday ~ Friday) to a date; n could be negative.
+func addWorkdays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step = -1
+		n = -n
+	}
+	if n == 0 {
+		return t
+	}
+	// starting from a weekend is the same as starting from the workday just before it
+	// (e.g. Saturday + 1 workday = Friday + 1 workday = Monday), thus every 5 workdays
+	// make a whole week then.
+	for !isWorkday(t) {
+		t = t.AddDate(0, 0, -step)
+	}
+	t = t.AddDate(0, 0, n/5*7*step)
+	n = n % 5
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if isWorkday(t) {
+			n--
+		}
+	}
+	return t
+}
+
+func isWorkday(t time.Time) bool {
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
+
+// isoWeekday - Monday = 1, ..., Sunday = 7
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
+
+// daysBetween - number of days from date2 to date1, i.e. date1 - date2
+func daysBetween(date1 time.Time, date2 time.Time) int {
+	return int((date1.Unix() - date2.Unix()) / 86400)
+}
+
+// fullYearsBetween - full years from birth to the date (i.e. 周岁), e.g. age
+func fullYearsBetween(birth time.Time, date time.Time) int {
+	years := date.Year() - birth.Year()
+	if date.Month() < birth.Month() || (date.Month() == birth.Month() && date.Day() < birth.Day()) {
+		years--
+	}
+	return years
+}
+
+// newDecimalFromDuration - get the duration in given unit as a decimal, e.g. 90分钟 in hours -> 1.5
+func newDecimalFromDuration(arith *Arith, d time.Duration, unit time.Duration) (*ZnDecimal, *error.Error) {
+	ns := &ZnDecimal{
+		co:       big.NewInt(int64(d)),
+		exp:      0,
+		ZnObject: NewZnObject(defaultDecimalClassRef),
+	}
+	result, err := arith.Div(ns, NewZnDecimalFromInt(int(unit), 0))
+	if err != nil {
+		return nil, err
+	}
+	// remove tail zeros of exact results, e.g. 1.5000000 -> 1.5
+	trimTailZeros(result)
+	return result, nil
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,266 @@
+package exec
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseZnDate(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect string
+		errStr string
+	}{
+		{"2024年3月5日", "2024年3月5日", ""},
+		{"2024-03-05", "2024年3月5日", ""},
+		{"2024/3/5", "2024年3月5日", ""},
+		{" 2024 年 12 月 31 日 ", "2024年12月31日", ""},
+		{"2024年2月29日", "2024年2月29日", ""},
+		{"2023年2月29日", "", "「2023年2月29日」不是有效的日期或时刻"},
+		{"2024年13月1日", "", "「2024年13月1日」不是有效的日期或时刻"},
+		{"2024年3月5日 14:30", "", "「2024年3月5日 14:30」不是有效的日期或时刻"},
+		{"3月5日", "", "「3月5日」不是有效的日期或时刻"},
+	}
+
+	for _, tt := range cases {
+		d, err := ParseZnDate(tt.input)
+		if tt.errStr != "" {
+			if err == nil || err.Error() != tt.errStr {
+				t.Errorf("ParseZnDate(%s) expect error -> %s, got -> %v", tt.input, tt.errStr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseZnDate(%s) expect no error, got -> %s", tt.input, err.Error())
+			continue
+		}
+		if d.String() != tt.expect {
+			t.Errorf("ParseZnDate(%s) expect -> %s, got -> %s", tt.input, tt.expect, d.String())
+		}
+	}
+}
+
+func TestParseZnTime(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect string
+		valid  bool
+	}{
+		{"2024年3月5日", "2024-03-05 00:00:00", true},
+		{"2024年3月5日 14:30", "2024-03-05 14:30:00", true},
+		{"2024-03-05 14:30:05", "2024-03-05 14:30:05", true},
+		{"2024年3月5日14时30分5秒", "2024-03-05 14:30:05", true},
+		{"2024年3月5日 9点15分", "2024-03-05 09:15:00", true},
+		{"2024年3月5日 24:00", "", false},
+		{"2024年3月5日 14", "", false},
+	}
+
+	for _, tt := range cases {
+		v, err := ParseZnTime(tt.input, time.UTC)
+		if !tt.valid {
+			if err == nil {
+				t.Errorf("ParseZnTime(%s) expect error, got no error", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseZnTime(%s) expect no error, got -> %s", tt.input, err.Error())
+			continue
+		}
+		if got := formatTime(v.Value, "YYYY-MM-DD HH:mm:ss"); got != tt.expect {
+			t.Errorf("ParseZnTime(%s) expect -> %s, got -> %s", tt.input, tt.expect, got)
+		}
+	}
+}
+
+func TestParseZnDuration(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect time.Duration
+		valid  bool
+	}{
+		{"3天", 72 * time.Hour, true},
+		{"1周2天", 9 * 24 * time.Hour, true},
+		{"3天4小时5分钟6秒", 76*time.Hour + 5*time.Minute + 6*time.Second, true},
+		{"1.5小时", 90 * time.Minute, true},
+		{"500毫秒", 500 * time.Millisecond, true},
+		{"负2分", -2 * time.Minute, true},
+		{"106751天", 106751 * 24 * time.Hour, true},
+		{"100000000天", 0, false},
+		{"负100000000天", 0, false},
+		{"3个月", 0, false},
+		{"3天x", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range cases {
+		d, err := ParseZnDuration(tt.input)
+		if !tt.valid {
+			if err == nil {
+				t.Errorf("ParseZnDuration(%s) expect error, got no error", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseZnDuration(%s) expect no error, got -> %s", tt.input, err.Error())
+			continue
+		}
+		if d.Value != tt.expect {
+			t.Errorf("ParseZnDuration(%s) expect -> %v, got -> %v", tt.input, tt.expect, d.Value)
+		}
+	}
+}
+
+func TestZnDuration_String(t *testing.T) {
+	cases := []struct {
+		input  time.Duration
+		expect string
+	}{
+		{0, "0秒"},
+		{76*time.Hour + 5*time.Minute + 6*time.Second, "3天4小时5分钟6秒"},
+		{1500 * time.Millisecond, "1秒500毫秒"},
+		{-90 * time.Minute, "负1小时30分钟"},
+	}
+
+	for _, tt := range cases {
+		if got := NewZnDuration(tt.input).String(); got != tt.expect {
+			t.Errorf("String() of %v expect -> %s, got -> %s", tt.input, tt.expect, got)
+		}
+	}
+}
+
+func TestGetTimeZone(t *testing.T) {
+	cases := []struct {
+		name   string
+		offset int
+		valid  bool
+	}{
+		{"UTC", 0, true},
+		{"北京时间", 8 * 3600, true},
+		{"UTC+8", 8 * 3600, true},
+		{"UTC-05:30", -(5*3600 + 30*60), true},
+		{"UTC+15", 0, false},
+		{"UTC8", 0, false},
+		{"火星/奥林匹斯", 0, false},
+		{"", 0, false},
+	}
+
+	moment := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range cases {
+		loc, err := GetTimeZone(tt.name)
+		if !tt.valid {
+			if err == nil {
+				t.Errorf("GetTimeZone(%s) expect error, got no error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("GetTimeZone(%s) expect no error, got -> %s", tt.name, err.Error())
+			continue
+		}
+		if _, offset := moment.In(loc).Zone(); offset != tt.offset {
+			t.Errorf("GetTimeZone(%s) expect offset -> %d, got -> %d", tt.name, tt.offset, offset)
+		}
+	}
+}
+
+func TestDurationRange(t *testing.T) {
+	cases := []struct {
+		amount string
+		unit   string
+		expect time.Duration
+		errStr string
+	}{
+		{"1.5", "小时", 90 * time.Minute, ""},
+		{"1E-40", "天", 0, ""},
+		{"-106751", "天", -106751 * 24 * time.Hour, ""},
+		{"1000000000000", "天", 0, "时长「1000000000000天」超出范围：时长须在约±292年之内"},
+		{"1E100000000", "秒", 0, "时长「1⏨100000000秒」超出范围：时长须在约±292年之内"},
+	}
+	for _, tt := range cases {
+		num, _ := NewZnDecimal(tt.amount)
+		d, err := newDurationFromDecimal(num, tt.unit)
+		if tt.errStr != "" {
+			if err == nil || err.Error() != tt.errStr {
+				t.Errorf("newDurationFromDecimal(%s，%s) expect error -> %s, got -> %v", tt.amount, tt.unit, tt.errStr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("newDurationFromDecimal(%s，%s) expect no error, got -> %s", tt.amount, tt.unit, err.Error())
+			continue
+		}
+		if d.Value != tt.expect {
+			t.Errorf("newDurationFromDecimal(%s，%s) expect -> %v, got -> %v", tt.amount, tt.unit, tt.expect, d.Value)
+		}
+	}
+
+	maxDuration := time.Duration(math.MaxInt64)
+	if _, ok := addDurations(maxDuration, time.Nanosecond); ok {
+		t.Errorf("addDurations() expect overflow")
+	}
+	if _, ok := subDurations(-maxDuration, 2*time.Nanosecond); ok {
+		t.Errorf("subDurations() expect overflow")
+	}
+	if d, ok := subDurations(-maxDuration, -maxDuration); !ok || d != 0 {
+		t.Errorf("subDurations() expect -> 0, got -> %v", d)
+	}
+}
+
+func TestDateCalculation(t *testing.T) {
+	date := func(y, m, d int) time.Time {
+		return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	}
+
+	if got := addMonths(date(2024, 1, 31), 1); !got.Equal(date(2024, 2, 29)) {
+		t.Errorf("addMonths() expect -> 2024-02-29, got -> %v", got)
+	}
+	if got := addMonths(date(2024, 3, 31), -13); !got.Equal(date(2023, 2, 28)) {
+		t.Errorf("addMonths() expect -> 2023-02-28, got -> %v", got)
+	}
+	// 2024-03-08 is Friday
+	if got := addWorkdays(date(2024, 3, 8), 1); !got.Equal(date(2024, 3, 11)) {
+		t.Errorf("addWorkdays() expect -> 2024-03-11, got -> %v", got)
+	}
+	if got := addWorkdays(date(2024, 3, 11), -1); !got.Equal(date(2024, 3, 8)) {
+		t.Errorf("addWorkdays() expect -> 2024-03-08, got -> %v", got)
+	}
+	if got := daysBetween(date(2024, 3, 1), date(2023, 3, 1)); got != 366 {
+		t.Errorf("daysBetween() expect -> 366, got -> %d", got)
+	}
+	if got := fullYearsBetween(date(2000, 2, 29), date(2024, 2, 28)); got != 23 {
+		t.Errorf("fullYearsBetween() expect -> 23, got -> %d", got)
+	}
+}
+
+func TestAddWorkdays(t *testing.T) {
+	// naiveAddWorkdays - step one day at a time
+	naiveAddWorkdays := func(t time.Time, n int) time.Time {
+		step := 1
+		if n < 0 {
+			step, n = -1, -n
+		}
+		for n > 0 {
+			t = t.AddDate(0, 0, step)
+			if isWorkday(t) {
+				n--
+			}
+		}
+		return t
+	}
+	// 2024-03-04 is Monday, cover all weekdays as the start
+	for day := 4; day <= 10; day++ {
+		start := time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC)
+		for n := -23; n <= 23; n++ {
+			if got, expect := addWorkdays(start, n), naiveAddWorkdays(start, n); !got.Equal(expect) {
+				t.Errorf("addWorkdays(%v, %d) expect -> %v, got -> %v", start, n, expect, got)
+			}
+		}
+	}
+	// large n should be computed immediately
+	start := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	if got := addWorkdays(start, maxDateOffset); !got.Equal(start.AddDate(0, 0, maxDateOffset/5*7)) {
+		t.Errorf("addWorkdays(%v, %d) got -> %v", start, maxDateOffset, got)
+	}
+}
@@ -14,7 +14,7 @@ const (
 	GlyphYIy rune = 0x4E49
 	// GlyphZHI - 之 - 此之，之
 	GlyphZHI rune = 0x4E4B
-	// GlyphYU - 于 - 等于，小于，大于，不等于，不小于，不大于
+	// GlyphYU - 于 - 等于，属于，小于，对于，大于，不等于，不小于，不大于
 	GlyphYU rune = 0x4E8E
 	// GlyphLING - 令 - 令
 	GlyphLING rune = 0x4EE4
@@ -22,10 +22,14 @@ const (
 	GlyphYIi rune = 0x4EE5
 	// GlyphHE - 何 - 如何，何为
 	GlyphHE rune = 0x4F55
+	// GlyphRUy - 入 - 导入
+	GlyphRUy rune = 0x5165
 	// GlyphQI - 其 - 其
 	GlyphQI rune = 0x5176
 	// GlyphZAI - 再 - 再如
 	GlyphZAI rune = 0x518D
+	// GlyphCHU - 出 - 抛出
+	GlyphCHU rune = 0x51FA
 	// GlyphZE - 则 - 否则
 	GlyphZE rune = 0x5219
 	// GlyphLI - 历 - 遍历
@@ -40,8 +44,16 @@ const (
 	GlyphRU rune = 0x5982
 	// GlyphDING - 定 - 定义
 	GlyphDING rune = 0x5B9A
+	// GlyphDUI - 对 - 对于
+	GlyphDUI rune = 0x5BF9
+	// GlyphDAO - 导 - 导入
+	GlyphDAO rune = 0x5BFC
 	// GlyphXIAO - 小 - 小于，不小于
 	GlyphXIAO rune = 0x5C0F
+	// GlyphCHANG - 尝 - 尝试
+	GlyphCHANG rune = 0x5C1D
+	// GlyphSHU - 属 - 属于
+	GlyphSHU rune = 0x5C5E
 	// GlyphYI - 已 - 已知
 	GlyphYI rune = 0x5DF2
 	// GlyphDANG - 当 - 每当
@@ -52,8 +64,16 @@ const (
 	GlyphCHENG rune = 0x6210
 	// GlyphHUO - 或 - 或
 	GlyphHUO rune = 0x6216
+	// GlyphCHENGy - 承 - 继承
+	GlyphCHENGy rune = 0x627F
+	// GlyphPAO - 抛 - 抛出
+	GlyphPAO rune = 0x629B
+	// GlyphBUy - 捕 - 捕获
+	GlyphBUy rune = 0x6355
 	// GlyphSHI - 是 - 是为
 	GlyphSHI rune = 0x662F
+	// GlyphZUI - 最 - 最终
+	GlyphZUI rune = 0x6700
 	// GlyphGUO - 果 - 如果
 	GlyphGUO rune = 0x679C
 	// GlyphCI - 此 - 此之
@@ -66,6 +86,14 @@ const (
 	GlyphZHIy rune = 0x77E5
 	// GlyphDENG - 等 - 等于，不等于
 	GlyphDENG rune = 0x7B49
+	// GlyphZHONG - 终 - 最终
+	GlyphZHONG rune = 0x7EC8
+	// GlyphJI - 继 - 继承
+	GlyphJI rune = 0x7EE7
+	// GlyphHUOy - 获 - 捕获
+	GlyphHUOy rune = 0x83B7
+	// GlyphSHIy - 试 - 尝试
+	GlyphSHIy rune = 0x8BD5
 	// GlyphFAN - 返 - 返回
 	GlyphFAN rune = 0x8FD4
 	// GlyphBIAN - 遍 - 遍历
@@ -78,10 +106,13 @@ var KeywordLeads = []rune{
 	GlyphZHI, GlyphLING, GlyphYIi,
 	GlyphHE, GlyphQI, GlyphZAI,
 	GlyphFOU, GlyphDA, GlyphRU,
-	GlyphDING, GlyphXIAO, GlyphYI,
-	GlyphHENG, GlyphCHENG, GlyphHUO,
-	GlyphSHI, GlyphCI, GlyphMEI,
-	GlyphDENG, GlyphFAN, GlyphBIAN,
+	GlyphDING, GlyphDUI, GlyphDAO,
+	GlyphXIAO, GlyphCHANG, GlyphSHU,
+	GlyphYI, GlyphHENG, GlyphCHENG,
+	GlyphHUO, GlyphPAO, GlyphBUy,
+	GlyphSHI, GlyphZUI, GlyphCI,
+	GlyphMEI, GlyphDENG, GlyphJI,
+	GlyphFAN, GlyphBIAN,
 }
 
 // Keyword token types
@@ -114,6 +145,14 @@ const (
 	TypeLogicEqualW   TokenType = 74 // 等于
 	TypeStaticSelfW   TokenType = 75 // 此之
 	TypeIteratorW     TokenType = 76 // 遍历
+	TypeTryW          TokenType = 77 // 尝试
+	TypeCatchW        TokenType = 78 // 捕获
+	TypeFinallyW      TokenType = 79 // 最终
+	TypeThrowW        TokenType = 80 // 抛出
+	TypeImportW       TokenType = 81 // 导入
+	TypeObjInheritW   TokenType = 82 // 继承
+	TypeMatchW        TokenType = 83 // 对于
+	TypeMatchTypeW    TokenType = 84 // 属于
 )
 
 // KeywordTypeMap -
@@ -146,6 +185,14 @@ var KeywordTypeMap = map[TokenType][]rune{
 	TypeLogicEqualW:   {GlyphDENG, GlyphYU},
 	TypeStaticSelfW:   {GlyphCI, GlyphZHI},
 	TypeIteratorW:     {GlyphBIAN, GlyphLI},
+	TypeTryW:          {GlyphCHANG, GlyphSHIy},
+	TypeCatchW:        {GlyphBUy, GlyphHUOy},
+	TypeFinallyW:      {GlyphZUI, GlyphZHONG},
+	TypeThrowW:        {GlyphPAO, GlyphCHU},
+	TypeImportW:       {GlyphDAO, GlyphRUy},
+	TypeObjInheritW:   {GlyphJI, GlyphCHENGy},
+	TypeMatchW:        {GlyphDUI, GlyphYU},
+	TypeMatchTypeW:    {GlyphSHU, GlyphYU},
 }
 
 // parseKeyword -
@@ -219,12 +266,12 @@ func (l *Lexer) parseKeyword(ch rune, moveForward bool) (bool, *Token) {
 			return false, nil
 		}
 	case GlyphRU:
-		if l.peek() == GlyphHE {
-			wordLen = 2
-			tk = NewKeywordToken(TypeFuncW)
-		} else if l.peek() == GlyphGUO {
+		if l.peek() == GlyphGUO {
 			wordLen = 2
 			tk = NewKeywordToken(TypeCondW)
+		} else if l.peek() == GlyphHE {
+			wordLen = 2
+			tk = NewKeywordToken(TypeFuncW)
 		} else {
 			return false, nil
 		}
@@ -235,6 +282,20 @@ func (l *Lexer) parseKeyword(ch rune, moveForward bool) (bool, *Token) {
 		} else {
 			return false, nil
 		}
+	case GlyphDUI:
+		if l.peek() == GlyphYU {
+			wordLen = 2
+			tk = NewKeywordToken(TypeMatchW)
+		} else {
+			return false, nil
+		}
+	case GlyphDAO:
+		if l.peek() == GlyphRUy {
+			wordLen = 2
+			tk = NewKeywordToken(TypeImportW)
+		} else {
+			return false, nil
+		}
 	case GlyphXIAO:
 		if l.peek() == GlyphYU {
 			wordLen = 2
@@ -242,6 +303,20 @@ func (l *Lexer) parseKeyword(ch rune, moveForward bool) (bool, *Token) {
 		} else {
 			return false, nil
 		}
+	case GlyphCHANG:
+		if l.peek() == GlyphSHIy {
+			wordLen = 2
+			tk = NewKeywordToken(TypeTryW)
+		} else {
+			return false, nil
+		}
+	case GlyphSHU:
+		if l.peek() == GlyphYU {
+			wordLen = 2
+			tk = NewKeywordToken(TypeMatchTypeW)
+		} else {
+			return false, nil
+		}
 	case GlyphYI:
 		if l.peek() == GlyphZHIy {
 			wordLen = 2
@@ -265,6 +340,20 @@ func (l *Lexer) parseKeyword(ch rune, moveForward bool) (bool, *Token) {
 		}
 	case GlyphHUO:
 		tk = NewKeywordToken(TypeLogicOrW)
+	case GlyphPAO:
+		if l.peek() == GlyphCHU {
+			wordLen = 2
+			tk = NewKeywordToken(TypeThrowW)
+		} else {
+			return false, nil
+		}
+	case GlyphBUy:
+		if l.peek() == GlyphHUOy {
+			wordLen = 2
+			tk = NewKeywordToken(TypeCatchW)
+		} else {
+			return false, nil
+		}
 	case GlyphSHI:
 		if l.peek() == GlyphWEI {
 			wordLen = 2
@@ -272,6 +361,13 @@ func (l *Lexer) parseKeyword(ch rune, moveForward bool) (bool, *Token) {
 		} else {
 			return false, nil
 		}
+	case GlyphZUI:
+		if l.peek() == GlyphZHONG {
+			wordLen = 2
+			tk = NewKeywordToken(TypeFinallyW)
+		} else {
+			return false, nil
+		}
 	case GlyphCI:
 		if l.peek() == GlyphZHI {
 			wordLen = 2
@@ -293,6 +389,13 @@ func (l *Lexer) parseKeyword(ch rune, moveForward bool) (bool, *Token) {
 		} else {
 			return false, nil
 		}
+	case GlyphJI:
+		if l.peek() == GlyphCHENGy {
+			wordLen = 2
+			tk = NewKeywordToken(TypeObjInheritW)
+		} else {
+			return false, nil
+		}
 	case GlyphFAN:
 		if l.peek() == GlyphHUI {
 			wordLen = 2
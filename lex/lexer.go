@@ -109,6 +109,12 @@ const (
 	DoubleArrow       rune = 0x27FA // ⟺
 	LeftCurlyBracket  rune = 0x007B // {
 	RightCurlyBracket rune = 0x007D // }
+	PlusMark          rune = 0xFF0B // ＋
+	MinusMark         rune = 0xFF0D // －
+	MultiplyMark      rune = 0x00D7 // ×
+	DivideMark        rune = 0x00F7 // ÷
+	PercentMark       rune = 0xFF05 // ％
+	ASCIIPercentMark  rune = 0x0025 // %
 )
 
 // MarkLeads -
@@ -116,9 +122,15 @@ var MarkLeads = []rune{
 	Comma, Colon, Semicolon, QuestionMark, RefMark, BangMark,
 	AnnotationMark, HashMark, EllipsisMark, LeftBracket,
 	RightBracket, LeftParen, RightParen, Equal, DoubleArrow,
-	LeftCurlyBracket, RightCurlyBracket,
+	LeftCurlyBracket, RightCurlyBracket, PlusMark, MinusMark,
+	MultiplyMark, DivideMark, PercentMark, ASCIIPercentMark,
 }
 
+// ASCIIArithMarks - since `+ - * /` are also valid chars of identifiers and numbers
+// (e.g. X+Y, -12.5), they're regarded as arithmetic operators only when separated
+// from operands by whitespaces (e.g. A + B, A - -12.5)
+var ASCIIArithMarks = []rune{'+', '-', '*', '/'}
+
 //// 3. spaces
 const (
 	SP  rune = 0x0020 // <SP>
@@ -261,6 +273,11 @@ const (
 	TypeStmtQuoteL  TokenType = 25 // {
 	TypeStmtQuoteR  TokenType = 26 // }
 	TypeMapQHash    TokenType = 27 // #{
+	TypeArithAdd    TokenType = 28 // + ＋
+	TypeArithSub    TokenType = 29 // - －
+	TypeArithMul    TokenType = 30 // * ×
+	TypeArithDiv    TokenType = 31 // / ÷
+	TypeArithMod    TokenType = 32 // % ％
 )
 
 // next - return current rune, and move forward the cursor for 1 character.
@@ -312,6 +329,12 @@ func (l *Lexer) SetBlockSize(size int) {
 	l.blockSize = size
 }
 
+// HasOpenQuotes - if there're quotes (of strings or multi-line comments) not closed yet.
+// Usually it's called after all tokens are parsed, to check if the input is incomplete.
+func (l *Lexer) HasOpenQuotes() bool {
+	return !l.quoteStack.IsEmpty()
+}
+
 // NextToken - parse and generate the next token (including comments)
 func (l *Lexer) NextToken() (tok *Token, err *error.Error) {
 	defer func() {
@@ -381,6 +404,17 @@ head:
 			l.consumeWhiteSpace(ch)
 			goto head
 		}
+		// parse ASCII arithmetic operators
+		if util.Contains(ch, ASCIIArithMarks) && l.isArithOperator(ch) {
+			tok, err = l.parseMarkers(ch)
+			return
+		}
+		// a sign right before an identifier is neither a number nor an operator, e.g. -A
+		if (ch == '+' || ch == '-') && isIdentifierChar(l.peek(), true) {
+			l.next()
+			err = error.SignBeforeIdentifier(ch)
+			return
+		}
 		// parse number
 		if isNumber(ch) || util.Contains(ch, []rune{'.', '+', '-'}) {
 			tok, err = l.parseNumber(ch)
@@ -390,6 +424,18 @@ head:
 			tok, err = l.parseMarkers(ch)
 			return
 		}
+		// parse money with currency symbol (e.g. ¥12.50)
+		if isCurrencySymbol(ch) {
+			tok, err = l.parseMoneySymbol(ch)
+			return
+		}
+		// parse Chinese numerals (e.g. 三千五百，百分之十五)
+		if isNumeralChar(ch) {
+			if isNumeral, tk := l.parseNumeral(ch); isNumeral {
+				tok = tk
+				return
+			}
+		}
 		// suppose it's a keyword
 		if isKeyword, tk := l.parseKeyword(ch, true); isKeyword {
 			tok = tk
@@ -651,6 +697,21 @@ func (l *Lexer) parseVarQuote(ch rune) (*Token, *error.Error) {
 	}
 }
 
+// isArithOperator - if the leading ASCII char (+ - * /) of a token is an arithmetic
+// operator. `*` and `/` are always operators; while `+` and `-` are regarded as the
+// sign of a number when followed by digits, dot or signs. When followed by identifier
+// chars (e.g. -A), they're neither operators nor signs, thus an error is reported.
+func (l *Lexer) isArithOperator(ch rune) bool {
+	if ch == '*' || ch == '/' {
+		return true
+	}
+	next := l.peek()
+	if isNumber(next) || util.Contains(next, []rune{'.', '+', '-'}) {
+		return false
+	}
+	return !isIdentifierChar(next, true)
+}
+
 // regex: ^[-+]?[0-9]*\.?[0-9]+((([eE][-+])|(\*(10)?\^[-+]?))[0-9]+)?$
 // ref: https://github.com/reg0007/Zn/issues/4
 func (l *Lexer) parseNumber(ch rune) (*Token, *error.Error) {
@@ -770,6 +831,15 @@ func (l *Lexer) parseNumber(ch rune) (*Token, *error.Error) {
 
 end:
 	if util.ContainsInt(state, endStates) {
+		// magnitude suffixes, percent mark or currency units right after the number, e.g. 1.2万，15%，12.50元
+		if state != sExpEnd && l.parseNumberSuffix(ch) {
+			// digits after magnitude suffixes are not allowed, e.g. 1万5
+			if next := l.peek(); isNumber(next) || next == '.' || isNumeralChar(next) {
+				return nil, error.InvalidNumberLiteral(string(append(l.chBuffer, next)))
+			}
+			rg.setRangeEnd(l)
+			return NewNumberToken(l.chBuffer, rg), nil
+		}
 		// back to last available char
 		l.rebase(l.cursor - 1)
 		rg.setRangeEnd(l)
@@ -778,6 +848,134 @@ end:
 	return nil, error.InvalidChar(ch)
 }
 
+// parseNumberSuffix - parse magnitude suffixes (万，亿), percent mark or currency units right after
+// a number, e.g. 1.2万，3万亿，15%，12.50元，3万美元. If matches, the cursor stays at the last char of the suffix.
+func (l *Lexer) parseNumberSuffix(ch rune) bool {
+	switch ch {
+	case NumeralWan, NumeralYi:
+		l.pushBuffer(ch)
+		for util.Contains(l.peek(), []rune{NumeralWan, NumeralYi}) {
+			l.pushBuffer(l.next())
+		}
+		l.parseCurrencyUnit(1)
+		return true
+	case ASCIIPercentMark, PercentMark:
+		// if an operand follows, it's regarded as a modulo operator, e.g. 7%3
+		next := l.peek()
+		if isNumber(next) || isIdentifierChar(next, true) ||
+			util.Contains(next, append([]rune{'.', '+', '-', MiddleDot, LeftParen, LeftBracket, LeftCurlyBracket}, LeftQuotes...)) {
+			return false
+		}
+		l.pushBuffer(ch)
+		return true
+	}
+	return l.parseCurrencyUnit(0)
+}
+
+// parseCurrencyUnit - parse currency unit (e.g. 元，美元) that starts from (cursor + offset).
+// The unit is accepted only if it's followed by a terminator (e.g. 12元 is valid, but 12元宝 is not);
+// and if matches, the cursor stays at the last char of the unit.
+func (l *Lexer) parseCurrencyUnit(offset int) bool {
+	for _, item := range CurrencyUnits {
+		unit := []rune(item.Unit)
+		matched := true
+		for idx, ch := range unit {
+			if l.getChar(l.cursor+offset+idx) != ch {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		prev := l.cursor
+		l.rebase(l.cursor + offset + len(unit) - 1)
+		if l.isNumeralEnd() {
+			l.pushBuffer(unit...)
+			return true
+		}
+		l.rebase(prev)
+	}
+	return false
+}
+
+// parseMoneySymbol - parse money literal that starts with a currency symbol, e.g. ¥12.50，￥1.2万
+func (l *Lexer) parseMoneySymbol(ch rune) (*Token, *error.Error) {
+	rg := newTokenRange(l)
+	next := l.next()
+	if !isNumber(next) && !util.Contains(next, []rune{'.', '+', '-'}) {
+		return nil, error.InvalidChar(next)
+	}
+	tk, err := l.parseNumber(next)
+	if err != nil {
+		return nil, err
+	}
+	literal := append([]rune{ch}, tk.Literal...)
+	// e.g. ¥15%，¥12元
+	if _, _, ok := SplitMoneyLiteral(literal); !ok {
+		return nil, error.InvalidChar(ch)
+	}
+	rg.setRangeEnd(l)
+	return NewNumberToken(literal, rg), nil
+}
+
+// parseNumeral - parse Chinese numerals (e.g. 三千五百，负三点一四，百分之十五) as a number.
+// If the chars are not a valid numeral or followed by other identifier chars (e.g. 三角形，一百分),
+// returns false and the cursor is restored, so that they would be parsed as an identifier.
+func (l *Lexer) parseNumeral(ch rune) (bool, *Token) {
+	startCursor := l.cursor
+	l.clearBuffer()
+	rg := newTokenRange(l)
+
+	l.pushBuffer(ch)
+	// 百分之 - arabic numbers are also allowed in the percentage, e.g. 百分之1.5
+	isPercent := false
+	if ch == NumeralBai && l.peek() == NumeralFen && l.peek2() == GlyphZHI {
+		l.pushBuffer(l.next(), l.next())
+		isPercent = true
+	}
+	for {
+		next := l.peek()
+		if isNumeralChar(next) || (isPercent && (isNumber(next) || next == '.')) {
+			l.pushBuffer(l.next())
+			continue
+		}
+		break
+	}
+
+	if _, ok := NormalizeNumber(l.chBuffer); ok {
+		// with currency unit, e.g. 三千五百元
+		if !isPercent && l.parseCurrencyUnit(1) || l.isNumeralEnd() {
+			rg.setRangeEnd(l)
+			return true, NewNumberToken(l.chBuffer, rg)
+		}
+	}
+	l.rebase(startCursor)
+	return false, nil
+}
+
+// isNumeralEnd - if the next char terminates a numeral, i.e. it's not an identifier char,
+// or it's the beginning of a keyword or a comment (e.g. 三千为，十五之).
+func (l *Lexer) isNumeralEnd() bool {
+	next := l.peek()
+	if !isIdentifierChar(next, true) && !isNumber(next) {
+		return true
+	}
+
+	prev := l.cursor
+	defer l.rebase(prev)
+
+	ch := l.next()
+	if isKeyword, _ := l.parseKeyword(ch, false); isKeyword {
+		return true
+	}
+	if ch == GlyphZHU {
+		validComment, _, _ := l.validateComment(ch)
+		return validComment
+	}
+	return false
+}
+
 // parseMarkers -
 func (l *Lexer) parseMarkers(ch rune) (*Token, *error.Error) {
 	// setup
@@ -833,6 +1031,16 @@ func (l *Lexer) parseMarkers(ch rune) (*Token, *error.Error) {
 		return nil, error.InvalidSingleEqual()
 	case DoubleArrow:
 		return NewMarkToken(l.chBuffer, TypeMapData, startR, 1), nil
+	case PlusMark, '+':
+		return NewMarkToken(l.chBuffer, TypeArithAdd, startR, 1), nil
+	case MinusMark, '-':
+		return NewMarkToken(l.chBuffer, TypeArithSub, startR, 1), nil
+	case MultiplyMark, '*':
+		return NewMarkToken(l.chBuffer, TypeArithMul, startR, 1), nil
+	case DivideMark, '/':
+		return NewMarkToken(l.chBuffer, TypeArithDiv, startR, 1), nil
+	case PercentMark, ASCIIPercentMark:
+		return NewMarkToken(l.chBuffer, TypeArithMod, startR, 1), nil
 	}
 	return nil, error.InvalidChar(ch)
 }
@@ -868,13 +1076,21 @@ func (l *Lexer) parseIdentifier(ch rune) (*Token, *error.Error) {
 	l.pushBuffer(ch)
 	count++
 	// iterate
+	var afterSpace = false
 	for {
 		prev := l.cursor
 		ch = l.next()
 
 		if isWhiteSpace(ch) {
+			afterSpace = true
 			continue
 		}
+		// ASCII arithmetic operators after whitespaces terminates the identifier (e.g. A + B)
+		if afterSpace && util.Contains(ch, ASCIIArithMarks) {
+			l.rebase(prev)
+			return NewIdentifierToken(l.chBuffer, rg), nil
+		}
+		afterSpace = false
 		// if the following chars are a keyword,
 		// then terminate the identifier parsing process.
 		if isKeyword, _ := l.parseKeyword(ch, false); isKeyword {
@@ -385,6 +385,217 @@ func TestNextToken_NumberONLY(t *testing.T) {
 			expectError: true,
 			errCursor:   8,
 		},
+		{
+			name:        "sign before identifier",
+			input:       "-A",
+			expectError: true,
+			errCursor:   1,
+		},
+	}
+
+	assertNextToken(cases, t)
+}
+
+func TestNextToken_NumeralONLY(t *testing.T) {
+	cases := []nextTokenCase{
+		{
+			name:        "chinese numeral",
+			input:       "三千五百，",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("三千五百"),
+			},
+		},
+		{
+			name:        "chinese numeral with dot and minus",
+			input:       "负三点一四 ",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("负三点一四"),
+			},
+		},
+		{
+			name:        "chinese numeral followed by keyword",
+			input:       "十五之绝对值",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("十五"),
+			},
+		},
+		{
+			name:        "chinese percentage",
+			input:       "百分之十五",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("百分之十五"),
+			},
+		},
+		{
+			name:        "chinese percentage with arabic number",
+			input:       "百分之1.5）",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("百分之1.5"),
+			},
+		},
+		{
+			name:        "number with magnitude suffix",
+			input:       "1.2万人",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("1.2万"),
+			},
+		},
+		{
+			name:        "money with currency symbol",
+			input:       "¥12.50，",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("¥12.50"),
+			},
+		},
+		{
+			name:        "money with full-width currency symbol & magnitude suffix",
+			input:       "￥1.2万",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("￥1.2万"),
+			},
+		},
+		{
+			name:        "money with currency unit",
+			input:       "12.50元为",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("12.50元"),
+			},
+		},
+		{
+			name:        "money with 2-char currency unit",
+			input:       "3万美元）",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("3万美元"),
+			},
+		},
+		{
+			name:        "chinese numeral money",
+			input:       "三千五百元，",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("三千五百元"),
+			},
+		},
+		{
+			name:        "currency unit followed by identifier chars",
+			input:       "12元宝",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("12"),
+			},
+		},
+		{
+			name:        "chinese numeral followed by currency unit & identifier chars",
+			input:       "一元二次方程",
+			expectError: false,
+			token: Token{
+				Type:    TypeIdentifier,
+				Literal: []rune("一元二次方程"),
+			},
+		},
+		{
+			name:        "currency symbol with percentage",
+			input:       "¥15%",
+			expectError: true,
+			errCursor:   3,
+		},
+		{
+			name:        "digits after magnitude suffix",
+			input:       "1万5",
+			expectError: true,
+			errCursor:   1,
+		},
+		{
+			name:        "chinese numerals after magnitude suffix",
+			input:       "1.2万五千",
+			expectError: true,
+			errCursor:   3,
+		},
+		{
+			name:        "number with multiple magnitude suffixes",
+			input:       "-3万亿",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("-3万亿"),
+			},
+		},
+		{
+			name:        "number with percent mark",
+			input:       "15%，",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("15%"),
+			},
+		},
+		{
+			name:        "number with fullwidth percent mark",
+			input:       "15％ ",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("15％"),
+			},
+		},
+		{
+			name:        "percent mark followed by operand is modulo",
+			input:       "15%4",
+			expectError: false,
+			token: Token{
+				Type:    TypeNumber,
+				Literal: []rune("15"),
+			},
+		},
+		{
+			name:        "numeral chars followed by identifier chars",
+			input:       "三角形",
+			expectError: false,
+			token: Token{
+				Type:    TypeIdentifier,
+				Literal: []rune("三角形"),
+			},
+		},
+		{
+			name:        "invalid numeral",
+			input:       "万一",
+			expectError: false,
+			token: Token{
+				Type:    TypeIdentifier,
+				Literal: []rune("万一"),
+			},
+		},
+		{
+			name:        "numeral in var quote",
+			input:       "·十五·",
+			expectError: false,
+			token: Token{
+				Type:    TypeVarQuote,
+				Literal: []rune("十五"),
+			},
+		},
 	}
 
 	assertNextToken(cases, t)
@@ -410,6 +621,16 @@ func TestNextToken_MarkerONLY(t *testing.T) {
 		"}":  TypeStmtQuoteR,
 		"==": TypeMapData,
 		"⟺":  TypeMapData,
+		"＋":  TypeArithAdd,
+		"－":  TypeArithSub,
+		"×":  TypeArithMul,
+		"÷":  TypeArithDiv,
+		"％":  TypeArithMod,
+		"+":  TypeArithAdd,
+		"-":  TypeArithSub,
+		"*":  TypeArithMul,
+		"/":  TypeArithDiv,
+		"%":  TypeArithMod,
 	}
 
 	var cases = make([]nextTokenCase, 0)
@@ -475,6 +696,24 @@ func TestNextToken_IdentifierONLY_SUCCESS(t *testing.T) {
 				Literal: []rune("正定/+_县/2345"),
 			},
 		},
+		{
+			name:        "normal identifier (arith operator after spaces as terminator)",
+			input:       "正定 - 县",
+			expectError: false,
+			token: Token{
+				Type:    TypeIdentifier,
+				Literal: []rune("正定"),
+			},
+		},
+		{
+			name:        "normal identifier (full-width arith operator as terminator)",
+			input:       "正定－县",
+			expectError: false,
+			token: Token{
+				Type:    TypeIdentifier,
+				Literal: []rune("正定"),
+			},
+		},
 		{
 			name:        "normal identifier (quote as terminator)",
 			input:       "正定县「」",
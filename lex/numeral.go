@@ -0,0 +1,378 @@
+package lex
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//// Chinese numerals & magnitude suffixes
+//
+// Besides ASCII numbers, the following forms of number literal are also supported:
+//
+// 1. Chinese numerals: 三千五百，十五，一万二千，两亿，负三点一四
+// 2. magnitude suffixes: 1.2万，3亿，5万亿
+// 3. percentages: 百分之十五，百分之1.5，15%
+// 4. money: ¥12.50，12.50元，三千五百元，20美元
+//
+// Those literals are transformed to ASCII form (see NormalizeNumber) before calculation.
+
+// declare numeral chars
+const (
+	NumeralWan   rune = 0x4E07 // 万
+	NumeralYi    rune = 0x4EBF // 亿
+	NumeralDian  rune = 0x70B9 // 点
+	NumeralFu    rune = 0x8D1F // 负
+	NumeralBai   rune = 0x767E // 百
+	NumeralFen   rune = 0x5206 // 分
+	NumeralLingO rune = 0x3007 // 〇
+)
+
+var numeralDigits = map[rune]int64{
+	'零': 0, NumeralLingO: 0, '一': 1, '二': 2, '两': 2, '三': 3,
+	'四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+var numeralUnits = map[rune]int64{
+	'十': 10, NumeralBai: 100, '千': 1000,
+}
+
+var numeralBigUnits = map[rune]int{
+	NumeralWan: 4, NumeralYi: 8,
+}
+
+// declare currency symbols
+const (
+	YenSign          rune = 0x00A5 // ¥
+	FullwidthYenSign rune = 0xFFE5 // ￥
+)
+
+// currencySymbols - currency symbols as the prefix of money literals, e.g. ¥12.50
+var currencySymbols = map[rune]string{
+	YenSign:          "CNY",
+	FullwidthYenSign: "CNY",
+}
+
+// CurrencyUnits - currency units as the suffix of money literals, e.g. 12.50元，20美元
+// NOTICE: longer units MUST be placed before shorter ones
+var CurrencyUnits = []struct {
+	Unit string
+	Code string
+}{
+	{"美元", "USD"},
+	{"欧元", "EUR"},
+	{"日元", "JPY"},
+	{"港元", "HKD"},
+	{"英镑", "GBP"},
+	{"元", "CNY"},
+}
+
+// percentPrefix - 百分之
+var percentPrefix = []rune{NumeralBai, NumeralFen, GlyphZHI}
+
+// isNumeralChar - if the char is one of Chinese numeral chars (including 点 and 负)
+func isNumeralChar(ch rune) bool {
+	if _, ok := numeralDigits[ch]; ok {
+		return true
+	}
+	if _, ok := numeralUnits[ch]; ok {
+		return true
+	}
+	if _, ok := numeralBigUnits[ch]; ok {
+		return true
+	}
+	return ch == NumeralDian || ch == NumeralFu
+}
+
+// IsChineseNumeral - if the number literal is written in Chinese numerals (e.g. 三千五百，百分之十五),
+// which might be mistaken as an identifier by users.
+func IsChineseNumeral(literal []rune) bool {
+	return len(literal) > 0 && isNumeralChar(literal[0])
+}
+
+// NormalizeNumber - transform a number literal to ASCII form that contains only
+// sign, digits, dot and exponent. e.g.:
+//
+// 三千五百 -> 3500
+// 1.2万 -> 1.2E4
+// 百分之十五 -> 15E-2
+// 15% -> 15E-2
+//
+// For ASCII number literals (e.g. 12.5E3), the literal is returned directly.
+// returns (normalized literal, isValid)
+func NormalizeNumber(literal []rune) (string, bool) {
+	if len(literal) == 0 {
+		return "", false
+	}
+	// 百分之XX
+	if hasRunePrefix(literal, percentPrefix) {
+		body := literal[len(percentPrefix):]
+		if num, ok := normalizePlainNumber(body); ok {
+			return withExp(num, -2), true
+		}
+		if num, ok := parseChineseNumeral(body); ok {
+			return withExp(num, -2), true
+		}
+		return "", false
+	}
+
+	last := literal[len(literal)-1]
+	// XX% (both ASCII & full-width)
+	if last == ASCIIPercentMark || last == PercentMark {
+		if num, ok := normalizePlainNumber(literal[:len(literal)-1]); ok {
+			return withExp(num, -2), true
+		}
+		return "", false
+	}
+	// XX万，XX亿
+	if _, ok := numeralBigUnits[last]; ok {
+		idx := len(literal)
+		exp := 0
+		for idx > 0 {
+			e, ok := numeralBigUnits[literal[idx-1]]
+			if !ok {
+				break
+			}
+			exp += e
+			idx--
+		}
+		if num, ok := normalizePlainNumber(literal[:idx]); ok {
+			return withExp(num, exp), true
+		}
+		// otherwise, it's a Chinese numeral (e.g. 三万)
+	}
+	for _, ch := range literal {
+		if ch > 0x7F {
+			return parseChineseNumeral(literal)
+		}
+	}
+	return string(literal), true
+}
+
+// isCurrencySymbol -
+func isCurrencySymbol(ch rune) bool {
+	_, ok := currencySymbols[ch]
+	return ok
+}
+
+// SplitMoneyLiteral - split a money literal into the number part and the currency code, e.g.:
+//
+// ¥12.50 -> (12.50, CNY)
+// 三千五百元 -> (三千五百, CNY)
+// 1.2万美元 -> (1.2万, USD)
+//
+// returns (number, currency code, isMoney). Percentages (e.g. 15%元) are not valid money literals.
+func SplitMoneyLiteral(literal []rune) ([]rune, string, bool) {
+	if len(literal) == 0 {
+		return nil, "", false
+	}
+	number, code := literal, ""
+	if c, ok := currencySymbols[literal[0]]; ok {
+		number, code = literal[1:], c
+	} else {
+		for _, item := range CurrencyUnits {
+			unit := []rune(item.Unit)
+			if len(literal) > len(unit) && string(literal[len(literal)-len(unit):]) == item.Unit {
+				number, code = literal[:len(literal)-len(unit)], item.Code
+				break
+			}
+		}
+	}
+	if code == "" || len(number) == 0 || hasRunePrefix(number, percentPrefix) {
+		return nil, "", false
+	}
+	if last := number[len(number)-1]; last == ASCIIPercentMark || last == PercentMark {
+		return nil, "", false
+	}
+	if _, ok := NormalizeNumber(number); !ok {
+		return nil, "", false
+	}
+	return number, code, true
+}
+
+// normalizePlainNumber - validate ASCII number without exponent, e.g. -12.5
+func normalizePlainNumber(literal []rune) (string, bool) {
+	digits := 0
+	dots := 0
+	for idx, ch := range literal {
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits++
+		case ch == '.':
+			dots++
+		case (ch == '+' || ch == '-') && idx == 0:
+		case ch == '_':
+		default:
+			return "", false
+		}
+	}
+	if digits == 0 || dots > 1 {
+		return "", false
+	}
+	return strings.Replace(string(literal), "_", "", -1), true
+}
+
+// parseChineseNumeral - transform Chinese numerals to ASCII decimal, e.g. 负三点一四 -> -3.14
+func parseChineseNumeral(literal []rune) (string, bool) {
+	sign := ""
+	if len(literal) > 0 && literal[0] == NumeralFu {
+		sign = "-"
+		literal = literal[1:]
+	}
+	intPart := literal
+	var fracPart []rune
+	for idx, ch := range literal {
+		if ch == NumeralDian {
+			intPart = literal[:idx]
+			fracPart = literal[idx+1:]
+			if len(fracPart) == 0 {
+				return "", false
+			}
+			break
+		}
+	}
+
+	intValue, ok := parseChineseInteger(intPart)
+	if !ok {
+		return "", false
+	}
+	result := sign + intValue.String()
+	// decimal part: only digits are allowed, e.g. 三点一四一五
+	if fracPart != nil {
+		frac := []byte{}
+		for _, ch := range fracPart {
+			d, ok := numeralDigits[ch]
+			if !ok || ch == '两' {
+				return "", false
+			}
+			frac = append(frac, byte('0'+d))
+		}
+		result = result + "." + string(frac)
+	}
+	return result, true
+}
+
+// parseChineseInteger - e.g. 三千五百 -> 3500, 一万零五 -> 10005
+//
+// Colloquial abbreviations are also supported, e.g. 三千五 -> 3500, 一万五 -> 15000
+func parseChineseInteger(literal []rune) (*big.Int, bool) {
+	const (
+		kNone = iota
+		kDigit
+		kZero
+		kUnit
+		kBigUnit
+	)
+	if len(literal) == 0 {
+		return nil, false
+	}
+	total := new(big.Int) // value of 亿 groups
+	wanPart := int64(0)   // value of 万 group (within current 亿 group)
+	section := int64(0)   // value of current section (below 万)
+	num := int64(0)       // current digit
+	lastUnit := int64(0)  // last unit (十，百，千) of current section
+	lastScale := int64(0) // scale of last unit (including 万，亿) for abbreviations
+	prev := kNone
+	wanUsed := false
+
+	// abbreviation: a digit right after a unit, e.g. 三千五 -> 三千五百
+	abbrev := func() {
+		if prev == kDigit && num > 0 && lastScale >= 10 {
+			num = num * (lastScale / 10)
+		}
+	}
+
+	for _, ch := range literal {
+		if d, ok := numeralDigits[ch]; ok {
+			if prev == kDigit {
+				return nil, false
+			}
+			if d == 0 {
+				// 零 could only be used alone, or between other numerals
+				if prev == kZero || prev == kNone && len(literal) > 1 {
+					return nil, false
+				}
+				prev = kZero
+				// digits after 零 would never be abbreviations
+				lastScale = -1
+				continue
+			}
+			num = d
+			prev = kDigit
+			continue
+		}
+		if u, ok := numeralUnits[ch]; ok {
+			if prev != kDigit {
+				// 十 could omit the leading 一 at the beginning, e.g. 十五
+				if u == 10 && prev == kNone {
+					num = 1
+				} else {
+					return nil, false
+				}
+			}
+			if lastUnit != 0 && u >= lastUnit {
+				return nil, false
+			}
+			section += num * u
+			num = 0
+			lastUnit = u
+			lastScale = u
+			prev = kUnit
+			continue
+		}
+		if e, ok := numeralBigUnits[ch]; ok {
+			if prev == kNone || prev == kZero {
+				return nil, false
+			}
+			abbrev()
+			switch ch {
+			case NumeralWan:
+				if wanUsed {
+					return nil, false
+				}
+				wanPart = (section + num) * 10000
+				wanUsed = true
+				lastScale = 10000
+			case NumeralYi:
+				groupValue := big.NewInt(wanPart + section + num)
+				total.Add(total, groupValue)
+				total.Mul(total, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(e)), nil))
+				wanPart = 0
+				wanUsed = false
+				lastScale = 100000000
+			}
+			section = 0
+			num = 0
+			lastUnit = 0
+			prev = kBigUnit
+			continue
+		}
+		return nil, false
+	}
+	if prev == kZero && len(literal) > 1 {
+		return nil, false
+	}
+	abbrev()
+	total.Add(total, big.NewInt(wanPart+section+num))
+	return total, true
+}
+
+// withExp - append exponent to a (normalized) number
+func withExp(num string, exp int) string {
+	if exp == 0 {
+		return num
+	}
+	return num + "E" + strconv.Itoa(exp)
+}
+
+func hasRunePrefix(s []rune, prefix []rune) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for idx, ch := range prefix {
+		if s[idx] != ch {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package lex
+
+import "testing"
+
+func TestNormalizeNumber(t *testing.T) {
+	cases := []struct {
+		input  string
+		expect string
+		valid  bool
+	}{
+		// ASCII numbers
+		{"12.5", "12.5", true},
+		{"-3E+5", "-3E+5", true},
+		// Chinese numerals
+		{"零", "0", true},
+		{"十", "10", true},
+		{"十五", "15", true},
+		{"二十", "20", true},
+		{"三千五百", "3500", true},
+		{"三千五", "3500", true},
+		{"一万五", "15000", true},
+		{"一万零五", "10005", true},
+		{"两万三千零一十", "23010", true},
+		{"三千五百万", "35000000", true},
+		{"一亿零五", "100000005", true},
+		{"一亿五", "150000000", true},
+		{"五万亿", "5000000000000", true},
+		{"一亿亿", "10000000000000000", true},
+		{"负三点一四", "-3.14", true},
+		{"零点零五", "0.05", true},
+		// magnitude suffixes & percentages
+		{"1.2万", "1.2E4", true},
+		{"3亿", "3E8", true},
+		{"-2万亿", "-2E12", true},
+		{"15%", "15E-2", true},
+		{"2.5％", "2.5E-2", true},
+		{"百分之十五", "15E-2", true},
+		{"百分之1.5", "1.5E-2", true},
+		{"百分之零点五", "0.5E-2", true},
+		// invalid cases
+		{"万", "", false},
+		{"一二", "", false},
+		{"零五", "", false},
+		{"五零", "", false},
+		{"一百一千", "", false},
+		{"一万万", "", false},
+		{"三点", "", false},
+		{"点五", "", false},
+		{"三点两", "", false},
+		{"百分之", "", false},
+		{"%", "", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := NormalizeNumber([]rune(tt.input))
+			if ok != tt.valid {
+				t.Errorf("NormalizeNumber() valid = %v, expect %v", ok, tt.valid)
+				return
+			}
+			if ok && got != tt.expect {
+				t.Errorf("NormalizeNumber() = %s, expect %s", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestSplitMoneyLiteral(t *testing.T) {
+	cases := []struct {
+		input  string
+		number string
+		code   string
+		valid  bool
+	}{
+		{"¥12.50", "12.50", "CNY", true},
+		{"￥1.2万", "1.2万", "CNY", true},
+		{"12.50元", "12.50", "CNY", true},
+		{"三千五百元", "三千五百", "CNY", true},
+		{"3万美元", "3万", "USD", true},
+		{"1000日元", "1000", "JPY", true},
+		{"12.50", "", "", false},
+		{"元", "", "", false},
+		{"¥15%", "", "", false},
+		{"15%元", "", "", false},
+		{"百分之十元", "", "", false},
+		{"¥12元", "", "", false},
+	}
+
+	for _, tt := range cases {
+		number, code, ok := SplitMoneyLiteral([]rune(tt.input))
+		if ok != tt.valid {
+			t.Errorf("SplitMoneyLiteral(%s) expect valid = %v, got %v", tt.input, tt.valid, ok)
+			continue
+		}
+		if ok && (string(number) != tt.number || code != tt.code) {
+			t.Errorf("SplitMoneyLiteral(%s) expect -> (%s, %s), got -> (%s, %s)", tt.input, tt.number, tt.code, string(number), code)
+		}
+	}
+}
@@ -106,6 +106,7 @@ type VDAssignPair struct {
 	Type       vdAssignPairTypeE
 	Variables  []*ID
 	AssignExpr Expression
+	ObjModule  *ID          // 成为 MM之XX：... may be nil if the class is not from an imported module
 	ObjClass   *ID          // 成为 XX： 1，2，3 ... valid only when Type = 2 (VDTypeObjNew)
 	ObjParams  []Expression // 成为 XX：P1，P2，P3，... valid only when Type = 2 (VDTypeObjNew)
 }
@@ -161,10 +162,20 @@ type BlockStmt struct {
 type FunctionDeclareStmt struct {
 	StmtBase
 	FuncName  *ID
-	ParamList []*ID
+	ParamList []*ParamItem
 	ExecBlock *BlockStmt
 }
 
+// ParamItem - a param definition of 已知 statement, e.g. A，税率为0.06，余项……
+type ParamItem struct {
+	ID *ID
+	// IsVariadic - if true, all rest arguments are collected into an array (e.g. 余项……).
+	// Only the last param could be variadic.
+	IsVariadic bool
+	// DefaultValue - evaluated at call time if the argument is not given (nil if no default value)
+	DefaultValue Expression
+}
+
 // GetterDeclareStmt - getter declaration (何为)
 type GetterDeclareStmt struct {
 	StmtBase
@@ -182,6 +193,8 @@ type FunctionReturnStmt struct {
 type ClassDeclareStmt struct {
 	StmtBase
 	ClassName *ID
+	// 继承XX (ParentClass may be nil if the class doesn't inherit from another one)
+	ParentClass *ID
 	// 其XX为XX
 	PropertyList []*PropertyDeclareStmt
 	// 是为XX，YY，ZZ
@@ -192,6 +205,63 @@ type ClassDeclareStmt struct {
 	GetterList []*GetterDeclareStmt
 }
 
+// TryStmt - 尝试 ... 捕获 ... 最终 ... statement
+type TryStmt struct {
+	StmtBase
+	// 尝试：
+	TryBlock *BlockStmt
+	// 捕获 XX：(CatchID may be nil if no identifier is given)
+	CatchID    *ID
+	CatchBlock *BlockStmt
+	// 最终：
+	FinallyBlock *BlockStmt
+}
+
+// MatchStmt - 对于 (expr) ： pattern matching statement with multiple arms
+type MatchStmt struct {
+	StmtBase
+	TargetExpr Expression
+	Arms       []*MatchArm
+	// 否则： (ElseBlock may be nil if there's no fallback arm)
+	ElseBlock *BlockStmt
+}
+
+// MatchArm - an arm of 对于 statement
+type MatchArm struct {
+	Type matchArmTypeE
+	// 为 V1，V2，... (valid only when Type = MatchArmValue)
+	Values []Expression
+	// 大于 A 且 小于 B (valid only when Type = MatchArmCompare)
+	// LeftExpr of each comparator is always nil, since it's the target value.
+	Comparators []*LogicExpr
+	// 属于 ClassName (valid only when Type = MatchArmType)
+	ClassName *ID
+	Block     *BlockStmt
+}
+
+type matchArmTypeE uint8
+
+// declare match arm types
+const (
+	MatchArmValue   = 1 // 为 1，2，3
+	MatchArmCompare = 2 // 大于 A 且 小于 B
+	MatchArmType    = 3 // 属于 ClassName
+)
+
+// ThrowStmt - 抛出 (expr)
+type ThrowStmt struct {
+	StmtBase
+	ThrowExpr Expression
+}
+
+// ImportStmt - 导入 「path」 (为 Namespace)
+type ImportStmt struct {
+	StmtBase
+	ImportPath *String
+	// Namespace may be nil - then the namespace is derived from the file name
+	Namespace *ID
+}
+
 // PropertyDeclareStmt - valid inside Class
 type PropertyDeclareStmt struct {
 	StmtBase
@@ -258,6 +328,19 @@ type FuncCallExpr struct {
 	Params   []Expression
 }
 
+// SpreadExpr - expand an array into arguments of function call, e.g. （求和：列表……）
+type SpreadExpr struct {
+	ExprBase
+	Expr Expression
+}
+
+// NamedParamExpr - pass an argument by param name, e.g. （计算税费：金额 == 100）
+type NamedParamExpr struct {
+	ExprBase
+	Name  *ID
+	Value Expression
+}
+
 // MemberExpr - declare a member (dot) relation
 // Example:
 //    此之 代码
@@ -318,6 +401,27 @@ type LogicExpr struct {
 	RightExpr Expression
 }
 
+// ArithTypeE - enumerates arithmetic operator types (ADD, SUB, etc)
+type ArithTypeE uint8
+
+// declare arithmetic types
+const (
+	ArithADD ArithTypeE = 1 // ＋
+	ArithSUB ArithTypeE = 2 // －
+	ArithMUL ArithTypeE = 3 // ×
+	ArithDIV ArithTypeE = 4 // ÷
+	ArithMOD ArithTypeE = 5 // ％
+	ArithNEG ArithTypeE = 6 // － (unary, negative)
+)
+
+// ArithExpr - arithmetic expression that yields a decimal
+type ArithExpr struct {
+	ExprBase
+	Type      ArithTypeE
+	LeftExpr  Expression // LeftExpr = nil when Type = ArithNEG
+	RightExpr Expression
+}
+
 // implement expression interface
 
 // SetLiteral - set literal for primeExpr
@@ -354,6 +458,10 @@ func ParseStatement(p *Parser) Statement {
 		lex.TypeVarOneW,
 		lex.TypeIteratorW,
 		lex.TypeObjDefineW,
+		lex.TypeTryW,
+		lex.TypeThrowW,
+		lex.TypeImportW,
+		lex.TypeMatchW,
 	}
 	match, tk := p.tryConsume(validTypes...)
 	if match {
@@ -379,6 +487,15 @@ func ParseStatement(p *Parser) Statement {
 			s = ParseIteratorStmt(p)
 		case lex.TypeObjDefineW:
 			s = ParseClassDeclareStmt(p)
+		case lex.TypeTryW:
+			mainIndent := p.getPeekIndent()
+			s = ParseTryStmt(p, mainIndent)
+		case lex.TypeThrowW:
+			s = ParseThrowStmt(p)
+		case lex.TypeImportW:
+			s = ParseImportStmt(p)
+		case lex.TypeMatchW:
+			s = ParseMatchStmt(p)
 		}
 		s.SetCurrentLine(tk)
 		return s
@@ -407,18 +524,15 @@ func ParseStatement(p *Parser) Statement {
 //       -> 不大于 VaE
 //       ->
 //
-// VaE   -> IdxE VaE'
-// VaE'  -> 为 IdxE
+// VaE   -> ArE VaE'
+// VaE'  -> 为 ArE
 //       ->
 //
-// IdxE  -> BsE IdxE'
-// IdxE' -> #  Number   IdxE'
-// IdxE' -> #  String   IdxE'
-//       -> #{  Expr  }  IdxE'
+// ArE   -> (see ParseArithExpr)
 //
 // precedences:
 //
-// # #{}  >  为  >  等于，大于，etc.  >  且  >  或
+// # #{}  >  ＋－×÷％  >  为  >  等于，大于，etc.  >  且  >  或
 func ParseExpression(p *Parser, asVarAssign bool) Expression {
 	var logicItemParser func(int) Expression
 	var logicItemTailParser func(int, Expression) Expression
@@ -454,7 +568,7 @@ func ParseExpression(p *Parser, asVarAssign bool) Expression {
 	//// anynomous function definition
 	logicItemParser = func(idx int) Expression {
 		if idx >= len(logicKeywords) {
-			return ParseMemberExpr(p)
+			return ParseArithExpr(p)
 		}
 		// #1. match item
 		expr1 := logicItemParser(idx + 1)
@@ -479,6 +593,7 @@ func ParseExpression(p *Parser, asVarAssign bool) Expression {
 			// usually for normal expressions (except 如果，每当 expr)
 			vid, ok := leftExpr.(Assignable)
 			if !ok {
+				panicNumeralAsID(leftExpr)
 				panic(error.ExprMustTypeID())
 			}
 			finalExpr = &VarAssignExpr{
@@ -505,6 +620,103 @@ func ParseExpression(p *Parser, asVarAssign bool) Expression {
 	return logicItemParser(0)
 }
 
+// ParseArithExpr - parse arithmetic expressions (both half-width & full-width
+// operators are supported)
+//
+// CFG:
+// ArE   -> MulE ArE'
+// ArE'  -> ＋ MulE ArE'
+//       -> － MulE ArE'
+//       ->
+//
+// MulE  -> NegE MulE'
+// MulE' -> × NegE MulE'
+//       -> ÷ NegE MulE'
+//       -> ％ NegE MulE'
+//       ->
+//
+// NegE  -> － NegE
+//       -> MemE
+//
+// NOTE: a signed number right after an operand (e.g. `A -2`) is regarded as
+// the operator and its operand (i.e. `A － 2`)
+//
+// precedences:
+//
+// － (negative)  >  ×，÷，％  >  ＋，－
+func ParseArithExpr(p *Parser) Expression {
+	var negParser func() Expression
+	var mulTailParser func(Expression) Expression
+	var arithTypeMap = map[lex.TokenType]ArithTypeE{
+		lex.TypeArithAdd: ArithADD,
+		lex.TypeArithSub: ArithSUB,
+		lex.TypeArithMul: ArithMUL,
+		lex.TypeArithDiv: ArithDIV,
+		lex.TypeArithMod: ArithMOD,
+	}
+
+	//// anynomous function definition
+	negParser = func() Expression {
+		if match, tk := p.tryConsume(lex.TypeArithSub); match {
+			expr := &ArithExpr{
+				Type:      ArithNEG,
+				RightExpr: negParser(),
+			}
+			expr.SetCurrentLine(tk)
+			return expr
+		}
+		return ParseMemberExpr(p)
+	}
+
+	//// anynomous function definition
+	mulTailParser = func(leftExpr Expression) Expression {
+		match, tk := p.tryConsume(lex.TypeArithMul, lex.TypeArithDiv, lex.TypeArithMod)
+		if !match {
+			return leftExpr
+		}
+		expr := &ArithExpr{
+			Type:      arithTypeMap[tk.Type],
+			LeftExpr:  leftExpr,
+			RightExpr: negParser(),
+		}
+		expr.SetCurrentLine(tk)
+		return mulTailParser(expr)
+	}
+
+	expr := mulTailParser(negParser())
+	for {
+		var finalExpr *ArithExpr
+		if match, tk := p.tryConsume(lex.TypeArithAdd, lex.TypeArithSub); match {
+			finalExpr = &ArithExpr{
+				Type:      arithTypeMap[tk.Type],
+				LeftExpr:  expr,
+				RightExpr: mulTailParser(negParser()),
+			}
+			finalExpr.SetCurrentLine(tk)
+		} else if tk := p.peek(); tk.Type == lex.TypeNumber && (tk.Literal[0] == '+' || tk.Literal[0] == '-') {
+			// a signed number right after an operand, e.g. A -2
+			if match, _ := p.tryConsume(lex.TypeNumber); !match {
+				return expr
+			}
+			num := newNumber(tk)
+			num.SetLiteral(tk.Literal[1:])
+
+			finalExpr = &ArithExpr{
+				Type:      ArithADD,
+				LeftExpr:  expr,
+				RightExpr: mulTailParser(num),
+			}
+			if tk.Literal[0] == '-' {
+				finalExpr.Type = ArithSUB
+			}
+			finalExpr.SetCurrentLine(tk)
+		} else {
+			return expr
+		}
+		expr = finalExpr
+	}
+}
+
 // ParseMemberExpr -
 //
 // CFG:
@@ -796,9 +1008,12 @@ func tryParseEmptyMapList(p *Parser) (bool, UnionMapList) {
 //
 // CFG:
 // FuncCallExpr  -> （ ID ： commaList ）
-// commaList     -> E commaListTail
-// commaListTail -> ， E commaListTail
+// commaList     -> P commaListTail
+// commaListTail -> ， P commaListTail
 //               ->
+// P             -> E
+//               -> E ……     (spread an array into arguments)
+//               -> ID == E   (named argument, no positional arguments are allowed after it)
 func ParseFuncCallExpr(p *Parser) *FuncCallExpr {
 	var callExpr = &FuncCallExpr{
 		Params: []Expression{},
@@ -809,8 +1024,29 @@ func ParseFuncCallExpr(p *Parser) *FuncCallExpr {
 	match, _ := p.tryConsume(lex.TypeFuncCall)
 	if match {
 		// #2.1 parse comma list
+		hasNamedParam := false
 		parseCommaList(p, func() {
-			expr := ParseExpression(p, true)
+			var expr Expression = ParseExpression(p, true)
+			if match, tk := p.tryConsume(lex.TypeMapData); match {
+				id, ok := expr.(*ID)
+				if !ok {
+					panicNumeralAsID(expr)
+					panic(error.ExprMustTypeID())
+				}
+				named := &NamedParamExpr{Name: id, Value: ParseExpression(p, true)}
+				named.SetCurrentLine(tk)
+				callExpr.Params = append(callExpr.Params, named)
+				hasNamedParam = true
+				return
+			}
+			if hasNamedParam {
+				panic(error.InvalidSyntaxCurr())
+			}
+			if match, tk := p.tryConsume(lex.TypeMoreParam); match {
+				spread := &SpreadExpr{Expr: expr}
+				spread.SetCurrentLine(tk)
+				expr = spread
+			}
 			callExpr.Params = append(callExpr.Params, expr)
 		})
 	}
@@ -928,13 +1164,20 @@ func parseVDAssignPair(p *Parser) VDAssignPair {
 			AssignExpr: expr,
 		}
 	default: // ObjNewW
+		var moduleName *ID
 		className := parseID(p)
+		// for classes from imported module, i.e. 成为 模块之类名
+		if match, _ := p.tryConsume(lex.TypeObjDotW); match {
+			moduleName = className
+			className = parseID(p)
+		}
 		// parse colon
 		match, _ := p.tryConsume(lex.TypeFuncCall)
 		if !match {
 			return VDAssignPair{
 				Type:      VDTypeObjNew,
 				Variables: idfList,
+				ObjModule: moduleName,
 				ObjClass:  className,
 				ObjParams: []Expression{},
 			}
@@ -949,6 +1192,7 @@ func parseVDAssignPair(p *Parser) VDAssignPair {
 		return VDAssignPair{
 			Type:      VDTypeObjNew,
 			Variables: idfList,
+			ObjModule: moduleName,
 			ObjClass:  className,
 			ObjParams: params,
 		}
@@ -1109,7 +1353,7 @@ func ParseBranchStmt(p *Parser, mainIndent int) *BranchStmt {
 //
 func ParseFunctionDeclareStmt(p *Parser) *FunctionDeclareStmt {
 	var fdStmt = &FunctionDeclareStmt{
-		ParamList: []*ID{},
+		ParamList: []*ParamItem{},
 	}
 	// by definition, when 已知 statement exists, it should be at first line
 	// of function block
@@ -1263,6 +1507,182 @@ func ParseFunctionReturnStmt(p *Parser) *FunctionReturnStmt {
 	}
 }
 
+// ParseTryStmt - yield TryStmt node
+// CFG:
+// TryStmt -> 尝试 ：
+//        ...     TryBlock
+//        ... 捕获 ID ：
+//        ...     CatchBlock
+//        ... 最终 ：
+//        ...     FinallyBlock
+//
+// where the identifier after 捕获 is optional; and at least one of
+// 捕获 and 最终 branches should be declared.
+func ParseTryStmt(p *Parser, mainIndent int) *TryStmt {
+	var stmt = new(TryStmt)
+
+	// parseBranchBlock - parse colon and the following block
+	parseBranchBlock := func() *BlockStmt {
+		p.consume(lex.TypeFuncCall)
+		ok, blockIndent := p.expectBlockIndent()
+		if !ok {
+			panic(error.UnexpectedIndent())
+		}
+		return ParseBlockStmt(p, blockIndent)
+	}
+
+	// #1. parse try block (the 尝试 keyword has been consumed)
+	stmt.TryBlock = parseBranchBlock()
+
+	// #2. parse catch block
+	if p.getPeekIndent() == mainIndent {
+		if match, _ := p.tryConsume(lex.TypeCatchW); match {
+			if p.peek().Type != lex.TypeFuncCall {
+				stmt.CatchID = parseID(p)
+			}
+			stmt.CatchBlock = parseBranchBlock()
+		}
+	}
+
+	// #3. parse finally block
+	if p.getPeekIndent() == mainIndent {
+		if match, _ := p.tryConsume(lex.TypeFinallyW); match {
+			stmt.FinallyBlock = parseBranchBlock()
+		}
+	}
+
+	if stmt.CatchBlock == nil && stmt.FinallyBlock == nil {
+		panic(error.IncompleteStmt())
+	}
+	return stmt
+}
+
+// ParseMatchStmt - yield MatchStmt node (without head token: 对于)
+// CFG:
+// MatchStmt -> 对于 TargetExpr ：
+//         ...     MatchArm1
+//         ...     MatchArm2
+//         ...     ....
+//         ...     否则 ：
+//         ...         ElseBlock
+//
+// MatchArm  -> 为 E1，E2，... ：                 (equals to any of the values)
+//         ...     Block
+//           -> 大于 E1 且 不大于 E2 ... ：        (comparators: 等于，不等于，大于，小于，不大于，不小于)
+//         ...     Block
+//           -> 属于 ClassID ：                   (the value is an instance of the class)
+//         ...     Block
+//
+// where the 否则 arm is optional, but it must be the last one if exists.
+func ParseMatchStmt(p *Parser) *MatchStmt {
+	var stmt = &MatchStmt{
+		Arms: []*MatchArm{},
+	}
+	var compareKeywords = []lex.TokenType{
+		lex.TypeLogicEqualW,
+		lex.TypeLogicNotEqW,
+		lex.TypeLogicGtW,
+		lex.TypeLogicGteW,
+		lex.TypeLogicLtW,
+		lex.TypeLogicLteW,
+	}
+	var compareTypeMap = map[lex.TokenType]LogicTypeE{
+		lex.TypeLogicEqualW: LogicEQ,
+		lex.TypeLogicNotEqW: LogicNEQ,
+		lex.TypeLogicGtW:    LogicGT,
+		lex.TypeLogicGteW:   LogicGTE,
+		lex.TypeLogicLtW:    LogicLT,
+		lex.TypeLogicLteW:   LogicLTE,
+	}
+
+	// parseArmBlock - parse colon and the following block
+	parseArmBlock := func() *BlockStmt {
+		p.consume(lex.TypeFuncCall)
+		ok, blockIndent := p.expectBlockIndent()
+		if !ok {
+			panic(error.UnexpectedIndent())
+		}
+		return ParseBlockStmt(p, blockIndent)
+	}
+
+	// #1. parse target expr & colon
+	stmt.TargetExpr = ParseExpression(p, false)
+	p.consume(lex.TypeFuncCall)
+
+	// #2. parse arms
+	ok, armIndent := p.expectBlockIndent()
+	if !ok {
+		panic(error.UnexpectedIndent())
+	}
+	parseItemListBlock(p, armIndent, func() {
+		var validArmTypes = append([]lex.TokenType{
+			lex.TypeComment,
+			lex.TypeLogicYesW,
+			lex.TypeMatchTypeW,
+			lex.TypeCondElseW,
+		}, compareKeywords...)
+
+		match, tk := p.tryConsume(validArmTypes...)
+		// no more arms are allowed after 否则
+		if !match || stmt.ElseBlock != nil && tk.Type != lex.TypeComment {
+			panic(error.InvalidSyntaxCurr())
+		}
+		arm := new(MatchArm)
+		switch tk.Type {
+		case lex.TypeComment:
+			return
+		case lex.TypeCondElseW:
+			stmt.ElseBlock = parseArmBlock()
+			return
+		case lex.TypeLogicYesW:
+			arm.Type = MatchArmValue
+			parseCommaList(p, func() {
+				arm.Values = append(arm.Values, ParseArithExpr(p))
+			})
+		case lex.TypeMatchTypeW:
+			arm.Type = MatchArmType
+			arm.ClassName = parseID(p)
+		default:
+			arm.Type = MatchArmCompare
+			for {
+				cmp := &LogicExpr{
+					Type:      compareTypeMap[tk.Type],
+					RightExpr: ParseArithExpr(p),
+				}
+				cmp.SetCurrentLine(tk)
+				arm.Comparators = append(arm.Comparators, cmp)
+				// 且 + comparator, e.g. 不小于60且小于90
+				if match, _ := p.tryConsume(lex.TypeLogicAndW); !match {
+					break
+				}
+				match, next := p.tryConsume(compareKeywords...)
+				if !match {
+					panic(error.InvalidSyntaxCurr())
+				}
+				tk = next
+			}
+		}
+		arm.Block = parseArmBlock()
+		stmt.Arms = append(stmt.Arms, arm)
+	})
+
+	if len(stmt.Arms) == 0 && stmt.ElseBlock == nil {
+		panic(error.IncompleteStmt())
+	}
+	return stmt
+}
+
+// ParseThrowStmt - yield ThrowStmt node (without head token: 抛出)
+//
+// CFG:
+// ThrowStmt -> 抛出 Expression
+func ParseThrowStmt(p *Parser) *ThrowStmt {
+	expr := ParseExpression(p, true)
+	return &ThrowStmt{
+		ThrowExpr: expr,
+	}
+}
+
 // ParseClassDeclareStmt - define class structure
 // A typical class may look like this:
 //
@@ -1283,6 +1703,8 @@ func ParseFunctionReturnStmt(p *Parser) *FunctionReturnStmt {
 // CFG:
 // ClassStmt  ->  定义 ClassID ：
 //                    ClassDeclareBlock
+//            ->  定义 ClassID 继承 ParentClassID ：
+//                    ClassDeclareBlock
 //
 // ClassDeclareBlock  -> ClassDeclareBlockItem1  ClassDeclareBlockItem2 ...
 //
@@ -1295,6 +1717,10 @@ func ParseClassDeclareStmt(p *Parser) *ClassDeclareStmt {
 	var cdStmt = new(ClassDeclareStmt)
 	// #1. consume ID
 	cdStmt.ClassName = parseID(p)
+	// #1.1 consume parent class ID (if exists)
+	if match, _ := p.tryConsume(lex.TypeObjInheritW); match {
+		cdStmt.ParentClass = parseID(p)
+	}
 
 	// #2. parse colon
 	p.consume(lex.TypeFuncCall)
@@ -1371,11 +1797,22 @@ func parsePropertyDeclareStmt(p *Parser) *PropertyDeclareStmt {
 func parseID(p *Parser) *ID {
 	match, tk := p.tryConsume(lex.TypeVarQuote, lex.TypeIdentifier)
 	if !match {
+		if peek := p.peek(); peek != nil && peek.Type == lex.TypeNumber && lex.IsChineseNumeral(peek.Literal) {
+			panic(error.NumeralAsIdentifier(string(peek.Literal)))
+		}
 		panic(error.InvalidSyntaxCurr())
 	}
 	return newID(tk)
 }
 
+// panicNumeralAsID - report a specific error when a Chinese numeral is used where an identifier
+// is expected, e.g. 十为10
+func panicNumeralAsID(expr Expression) {
+	if num, ok := expr.(*Number); ok && lex.IsChineseNumeral([]rune(num.GetLiteral())) {
+		panic(error.NumeralAsIdentifier(num.GetLiteral()))
+	}
+}
+
 func parseCommaList(p *Parser, consumer consumerFunc) {
 	// first item MUST be consumed!
 	consumer()
@@ -1391,21 +1828,44 @@ func parseCommaList(p *Parser, consumer consumerFunc) {
 	}
 }
 
-func parseParamDefList(p *Parser, allowBreak bool) []*ID {
+// parseParamDefList - parse params of 已知 statement
+// CFG:
+// ParamDefList -> P1，P2，...
+// P            -> ID
+//              -> ID 为 E    (param with default value)
+//              -> ID ……     (variadic param, must be the last one)
+//
+// params without default values (except the variadic one) should be placed before those with defaults.
+func parseParamDefList(p *Parser, allowBreak bool) []*ParamItem {
 	defer func() {
 		if allowBreak {
 			p.resetLineTermFlag()
 		}
 	}()
-	var idList = []*ID{}
+	var paramList = []*ParamItem{}
+	var hasDefault = false
 
 	// parse param lists
 	parseCommaList(p, func() {
-		idItem := parseID(p)
-		idList = append(idList, idItem)
+		// no more params are allowed after the variadic one
+		if len(paramList) > 0 && paramList[len(paramList)-1].IsVariadic {
+			panic(error.InvalidSyntaxCurr())
+		}
+		item := &ParamItem{ID: parseID(p)}
+		match, tk := p.tryConsume(lex.TypeMoreParam, lex.TypeLogicYesW)
+		switch {
+		case match && tk.Type == lex.TypeMoreParam:
+			item.IsVariadic = true
+		case match && tk.Type == lex.TypeLogicYesW:
+			item.DefaultValue = ParseExpression(p, false)
+			hasDefault = true
+		case hasDefault:
+			panic(error.InvalidSyntaxCurr())
+		}
+		paramList = append(paramList, item)
 	})
 
-	return idList
+	return paramList
 }
 
 func parseItemListBlock(p *Parser, blockIndent int, consumer func()) {
@@ -1463,3 +1923,22 @@ func NewNumberNode(tk *lex.Token) *Number {
 func NewStringNode(tk *lex.Token) *String {
 	return newString(tk)
 }
+
+// ParseImportStmt - yield ImportStmt node (without head token: 导入)
+//
+// CFG:
+// ImportStmt -> 导入 String
+//            -> 导入 String 为 ID
+func ParseImportStmt(p *Parser) *ImportStmt {
+	stmt := new(ImportStmt)
+	match, tk := p.tryConsume(lex.TypeString)
+	if !match {
+		panic(error.InvalidSyntaxCurr())
+	}
+	stmt.ImportPath = newString(tk)
+
+	if match, _ := p.tryConsume(lex.TypeLogicYesW); match {
+		stmt.Namespace = parseID(p)
+	}
+	return stmt
+}
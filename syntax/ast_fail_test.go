@@ -13,6 +13,9 @@ var testFailSuites = []string{
 	whileLoopCasesFAIL,
 	funcCallCasesFAIL,
 	arrayListCasesFAIL,
+	tryStmtCasesFAIL,
+	matchStmtCasesFAIL,
+	importStmtCasesFAIL,
 }
 
 const varDeclCasesFAIL = `
@@ -101,6 +104,21 @@ code=2250 line=1 col=0
 令A为1，B为2
 --------
 code=2250 line=1 col=4
+
+========
+10. chinese numeral as identifier (NumeralAsIdentifier)
+--------
+令十为10
+--------
+code=2256 line=1 col=1
+
+========
+11. assign to chinese numeral (NumeralAsIdentifier)
+--------
+令A为1
+五为3
+--------
+code=2256 line=2 col=3
 `
 
 const whileLoopCasesFAIL = `
@@ -187,6 +205,38 @@ code=2250 line=1 col=13
 （显示时间：「2020」，，500）
 --------
 code=2250 line=1 col=13
+
+========
+6. param after variadic param
+--------
+如何求和？
+	已知余项……，末项
+	返回末项
+--------
+code=2250 line=2 col=7
+
+========
+7. param without default value after those with defaults
+--------
+如何计算？
+	已知税率为0.06，金额
+	返回金额
+--------
+code=2250 line=2 col=11
+
+========
+8. positional param after named param
+--------
+（计算税费：税率 == 0.13，100）
+--------
+code=2250 line=1 col=17
+
+========
+9. chinese numeral as param name (NumeralAsIdentifier)
+--------
+（计算税费：十 == 0.13）
+--------
+code=2256 line=1 col=11
 `
 
 const arrayListCasesFAIL = `
@@ -226,6 +276,64 @@ code=2250 line=1 col=5
 code=2255 line=1 col=13
 `
 
+const tryStmtCasesFAIL = `
+========
+1. try without catch or finally
+--------
+尝试：
+	A为B
+C为D
+--------
+code=2252 line=3 col=0
+
+========
+2. catch block without indent
+--------
+尝试：
+	A为B
+捕获：
+C为D
+--------
+code=2251 line=4 col=0
+`
+
+const matchStmtCasesFAIL = `
+========
+1. match without arms
+--------
+对于A：
+C为D
+--------
+code=2251 line=2 col=0
+
+========
+2. arm after fallback arm
+--------
+对于A：
+	否则：
+		B为1
+	为1：
+		B为2
+--------
+code=2250 line=4 col=1
+`
+
+const importStmtCasesFAIL = `
+========
+1. import path is not a string
+--------
+导入工具
+--------
+code=2250 line=1 col=0
+
+========
+2. namespace is missing
+--------
+导入「工具.zn」为
+--------
+code=2250 line=1 col=9
+`
+
 type astFailCase struct {
 	name     string
 	input    string
@@ -19,6 +19,10 @@ var testSuccessSuites = []string{
 	memberExprCasesOK,
 	iterateCasesOK,
 	classDeclareCasesOK,
+	tryStmtCasesOK,
+	matchStmtCasesOK,
+	importStmtCasesOK,
+	arithExprCasesOK,
 }
 
 const logicExprCasesOK = `
@@ -306,6 +310,62 @@ $PG($BK(
 		$FN(name=($ID(显示时刻)) params=())
 	))
 ))
+
+========
+7. spread arrays into parameters
+--------
+（求和：1，列表……，【2，3】……）
+--------
+$PG($BK(
+	$FN(name=($ID(求和)) params=(
+		$NUM(1)
+		$MORE($ID(列表))
+		$MORE($ARR($NUM(2) $NUM(3)))
+	))
+))
+
+========
+8. declare function with variadic parameter
+--------
+如何求和？
+	已知首项，余项……
+	返回首项
+--------
+$PG($BK(
+	$FN(
+		name=($ID(求和))
+		params=($ID(首项) $MORE($ID(余项)))
+		blockTokens=($BK($RT($ID(首项))))
+	)
+))
+
+========
+9. declare function with default values
+--------
+如何计算税费？
+	已知金额，税率为0.06，余项……
+	返回金额
+--------
+$PG($BK(
+	$FN(
+		name=($ID(计算税费))
+		params=($ID(金额) $OPT(id=($ID(税率)) default=($NUM(0.06))) $MORE($ID(余项)))
+		blockTokens=($BK($RT($ID(金额))))
+	)
+))
+
+========
+10. named parameters
+--------
+（计算税费：100，税率 == 0.13，附加 == 金额×2）
+--------
+$PG($BK(
+	$FN(name=($ID(计算税费)) params=(
+		$NUM(100)
+		$NAMED(name=($ID(税率)) value=($NUM(0.13)))
+		$NAMED(name=($ID(附加)) value=($MUL(L=($ID(金额)) R=($NUM(2)))))
+	))
+))
 `
 
 const branchStmtCasesOK = `
@@ -974,6 +1034,279 @@ $PG($BK(
 	)
 ))
 
+========
+4. class definition with parent class
+--------
+定义柯基继承狗：
+	其腿长为10
+
+	何为总和？
+		返回30
+--------
+$PG($BK(
+	$CLS(
+		name=($ID(柯基))
+		parent=($ID(狗))
+		properties=(
+			$PD(id=($ID(腿长)) expr=($NUM(10)))
+		)
+		constructor=()
+		methods=()
+		getters=(
+			$GT(
+				name=($ID(总和))
+				blockTokens=($BK(
+					$RT($NUM(30))
+				))
+			)
+		)
+	)
+))
+`
+
+const tryStmtCasesOK = `
+========
+1. try-catch with identifier
+--------
+尝试：
+	令A为B
+捕获错误：
+	（显示：错误）
+--------
+$PG($BK(
+	$TRY(
+		try=($BK($VD($VP(vars[]=($ID(A)) expr[]=($ID(B))))))
+		catchID=($ID(错误))
+		catch=($BK($FN(name=($ID(显示)) params=($ID(错误)))))
+	)
+))
+
+========
+2. try-finally without catch
+--------
+尝试：
+	A为B
+最终：
+	C为D
+--------
+$PG($BK(
+	$TRY(
+		try=($BK($VA(target=($ID(A)) assign=($ID(B)))))
+		finally=($BK($VA(target=($ID(C)) assign=($ID(D)))))
+	)
+))
+
+========
+3. try-catch-finally (catch without identifier)
+--------
+尝试：
+	抛出「余额不足」
+捕获：
+	A为1
+最终：
+	B为2
+--------
+$PG($BK(
+	$TRY(
+		try=($BK($THROW($STR(余额不足))))
+		catchID=()
+		catch=($BK($VA(target=($ID(A)) assign=($NUM(1)))))
+		finally=($BK($VA(target=($ID(B)) assign=($NUM(2)))))
+	)
+))
+
+========
+4. nested try statements
+--------
+尝试：
+	尝试：
+		抛出E
+	捕获E：
+		抛出E
+捕获F：
+	F
+--------
+$PG($BK(
+	$TRY(
+		try=($BK(
+			$TRY(
+				try=($BK($THROW($ID(E))))
+				catchID=($ID(E))
+				catch=($BK($THROW($ID(E))))
+			)
+		))
+		catchID=($ID(F))
+		catch=($BK($ID(F)))
+	)
+))
+`
+
+const matchStmtCasesOK = `
+========
+1. value arms with fallback
+--------
+对于A：
+	为1，2：
+		B为1
+	为「甲」：
+		B为2
+	否则：
+		B为3
+--------
+$PG($BK(
+	$MATCH(
+		target=($ID(A))
+		arm[]=(values=($NUM(1) $NUM(2)) block=($BK($VA(target=($ID(B)) assign=($NUM(1))))))
+		arm[]=(values=($STR(甲)) block=($BK($VA(target=($ID(B)) assign=($NUM(2))))))
+		else=($BK($VA(target=($ID(B)) assign=($NUM(3)))))
+	)
+))
+
+========
+2. comparator arms joined with 且
+--------
+对于分数：
+	不小于90：
+		B为1
+	不小于60且小于90：
+		B为2
+--------
+$PG($BK(
+	$MATCH(
+		target=($ID(分数))
+		arm[]=(compare=($GTE(L=() R=($NUM(90)))) block=($BK($VA(target=($ID(B)) assign=($NUM(1))))))
+		arm[]=(compare=($GTE(L=() R=($NUM(60))) $LT(L=() R=($NUM(90)))) block=($BK($VA(target=($ID(B)) assign=($NUM(2))))))
+	)
+))
+
+========
+3. class type arm & hashmap shape arm
+--------
+对于A：
+	属于动物：
+		B为1
+	为【「名称」== 名】：
+		B为名
+--------
+$PG($BK(
+	$MATCH(
+		target=($ID(A))
+		arm[]=(type=($ID(动物)) block=($BK($VA(target=($ID(B)) assign=($NUM(1))))))
+		arm[]=(values=($HM(key[]=($STR(名称)) value[]=($ID(名)))) block=($BK($VA(target=($ID(B)) assign=($ID(名))))))
+	)
+))
+`
+
+const importStmtCasesOK = `
+========
+1. import without namespace
+--------
+导入「工具.zn」
+--------
+$PG($BK($IMPORT(path=($STR(工具.zn)) as=())))
+
+========
+2. import with namespace
+--------
+导入「../lib/常用工具」为工具
+--------
+$PG($BK($IMPORT(path=($STR(../lib/常用工具)) as=($ID(工具)))))
+
+========
+3. new object from imported module
+--------
+导入「订单.zn」为订单模块
+令A成为订单模块之订单：「玻璃」，10
+--------
+$PG($BK(
+	$IMPORT(path=($STR(订单.zn)) as=($ID(订单模块)))
+	$VD($VP(object vars[]=($ID(A)) class=($ID(订单模块)之$ID(订单)) params[]=($STR(玻璃) $NUM(10))))
+))
+`
+
+const arithExprCasesOK = `
+========
+1. full-width operators with precedence
+--------
+A＋B×C－D÷E％F
+--------
+$PG($BK(
+	$SUB(
+		L=($ADD(
+			L=($ID(A))
+			R=($MUL(L=($ID(B)) R=($ID(C))))
+		))
+		R=($MOD(
+			L=($DIV(L=($ID(D)) R=($ID(E))))
+			R=($ID(F))
+		))
+	)
+))
+
+========
+2. half-width operators separated by spaces
+--------
+单价 * 数量 - 折扣 / 2
+--------
+$PG($BK(
+	$SUB(
+		L=($MUL(L=($ID(单价)) R=($ID(数量))))
+		R=($DIV(L=($ID(折扣)) R=($NUM(2))))
+	)
+))
+
+========
+3. unary minus & signed number after operand
+--------
+－A × -3 -2
+--------
+$PG($BK(
+	$SUB(
+		L=($MUL(L=($NEG($ID(A))) R=($NUM(-3))))
+		R=($NUM(2))
+	)
+))
+
+========
+4. arith expr has higher precedence than comparison & assignment
+--------
+A为B＋1
+B＋1大于{C－1}×2
+--------
+$PG($BK(
+	$VA(
+		target=($ID(A))
+		assign=($ADD(L=($ID(B)) R=($NUM(1))))
+	)
+	$GT(
+		L=($ADD(L=($ID(B)) R=($NUM(1))))
+		R=($MUL(L=($SUB(L=($ID(C)) R=($NUM(1)))) R=($NUM(2))))
+	)
+))
+
+========
+5. member expr & function call as operands
+--------
+订单之金额 × （求和：1，2） + 其运费#0
+--------
+$PG($BK(
+	$ADD(
+		L=($MUL(
+			L=($MB(root=($ID(订单)) type=(mID) object=($ID(金额))))
+			R=($FN(name=($ID(求和)) params=($NUM(1) $NUM(2))))
+		))
+		R=($MB(root=($MB(rootProp type=(mID) object=($ID(运费)))) type=(mIndex) object=($NUM(0))))
+	)
+))
+
+========
+6. X+Y as identifier (without spaces)
+--------
+（X+Y：A-1，-2）
+--------
+$PG($BK(
+	$FN(name=($ID(X+Y)) params=($ID(A-1) $NUM(-2)))
+))
 `
 
 type astSuccessCase struct {
@@ -44,6 +44,22 @@ func StringifyAST(node Node) string {
 		lstr := StringifyAST(v.LeftExpr)
 		rstr := StringifyAST(v.RightExpr)
 		return fmt.Sprintf("%s(L=(%s) R=(%s))", typeStrMap[v.Type], lstr, rstr)
+	case *ArithExpr:
+		var typeStrMap = map[ArithTypeE]string{
+			ArithADD: "$ADD",
+			ArithSUB: "$SUB",
+			ArithMUL: "$MUL",
+			ArithDIV: "$DIV",
+			ArithMOD: "$MOD",
+			ArithNEG: "$NEG",
+		}
+
+		rstr := StringifyAST(v.RightExpr)
+		if v.Type == ArithNEG {
+			return fmt.Sprintf("%s(%s)", typeStrMap[v.Type], rstr)
+		}
+		lstr := StringifyAST(v.LeftExpr)
+		return fmt.Sprintf("%s(L=(%s) R=(%s))", typeStrMap[v.Type], lstr, rstr)
 	case *MemberExpr:
 		var str = ""
 		var sType = ""
@@ -92,10 +108,14 @@ func StringifyAST(node Node) string {
 				for _, vp := range vpair.ObjParams {
 					paramsStr = append(paramsStr, StringifyAST(vp))
 				}
+				classStr := StringifyAST(vpair.ObjClass)
+				if vpair.ObjModule != nil {
+					classStr = fmt.Sprintf("%s之%s", StringifyAST(vpair.ObjModule), classStr)
+				}
 				items = append(items, fmt.Sprintf(
 					"$VP(object vars[]=(%s) class=(%s) params[]=(%s))",
 					strings.Join(vars, " "),
-					StringifyAST(vpair.ObjClass),
+					classStr,
 					strings.Join(paramsStr, " "),
 				))
 			}
@@ -118,6 +138,10 @@ func StringifyAST(node Node) string {
 		}
 
 		return fmt.Sprintf("$FN(name=(%s) params=(%s))", name, strings.Join(params, " "))
+	case *SpreadExpr:
+		return fmt.Sprintf("$MORE(%s)", StringifyAST(v.Expr))
+	case *NamedParamExpr:
+		return fmt.Sprintf("$NAMED(name=(%s) value=(%s))", StringifyAST(v.Name), StringifyAST(v.Value))
 	case *BranchStmt:
 		var conds = []string{}
 		// add if-branch
@@ -147,7 +171,15 @@ func StringifyAST(node Node) string {
 	case *FunctionDeclareStmt:
 		paramsStr := []string{}
 		for _, p := range v.ParamList {
-			paramsStr = append(paramsStr, StringifyAST(p))
+			if p.IsVariadic {
+				paramsStr = append(paramsStr, fmt.Sprintf("$MORE(%s)", StringifyAST(p.ID)))
+				continue
+			}
+			if p.DefaultValue != nil {
+				paramsStr = append(paramsStr, fmt.Sprintf("$OPT(id=(%s) default=(%s))", StringifyAST(p.ID), StringifyAST(p.DefaultValue)))
+				continue
+			}
+			paramsStr = append(paramsStr, StringifyAST(p.ID))
 		}
 
 		return fmt.Sprintf("$FN(name=(%s) params=(%s) blockTokens=(%s))",
@@ -192,14 +224,70 @@ func StringifyAST(node Node) string {
 			getterStr = append(getterStr, StringifyAST(g))
 		}
 
+		parentStr := ""
+		if v.ParentClass != nil {
+			parentStr = fmt.Sprintf(" parent=(%s)", StringifyAST(v.ParentClass))
+		}
 		return fmt.Sprintf(
-			"$CLS(name=(%s) properties=(%s) constructor=(%s) methods=(%s) getters=(%s))",
+			"$CLS(name=(%s)%s properties=(%s) constructor=(%s) methods=(%s) getters=(%s))",
 			StringifyAST(v.ClassName),
+			parentStr,
 			strings.Join(propertyStr, " "),
 			strings.Join(constructorStr, " "),
 			strings.Join(methodStr, " "),
 			strings.Join(getterStr, " "),
 		)
+	case *TryStmt:
+		items := []string{
+			fmt.Sprintf("try=(%s)", StringifyAST(v.TryBlock)),
+		}
+		if v.CatchBlock != nil {
+			catchID := ""
+			if v.CatchID != nil {
+				catchID = StringifyAST(v.CatchID)
+			}
+			items = append(items, fmt.Sprintf("catchID=(%s) catch=(%s)", catchID, StringifyAST(v.CatchBlock)))
+		}
+		if v.FinallyBlock != nil {
+			items = append(items, fmt.Sprintf("finally=(%s)", StringifyAST(v.FinallyBlock)))
+		}
+		return fmt.Sprintf("$TRY(%s)", strings.Join(items, " "))
+	case *MatchStmt:
+		items := []string{
+			fmt.Sprintf("target=(%s)", StringifyAST(v.TargetExpr)),
+		}
+		for _, arm := range v.Arms {
+			var pattern string
+			switch arm.Type {
+			case MatchArmValue:
+				values := []string{}
+				for _, value := range arm.Values {
+					values = append(values, StringifyAST(value))
+				}
+				pattern = fmt.Sprintf("values=(%s)", strings.Join(values, " "))
+			case MatchArmCompare:
+				comparators := []string{}
+				for _, cmp := range arm.Comparators {
+					comparators = append(comparators, StringifyAST(cmp))
+				}
+				pattern = fmt.Sprintf("compare=(%s)", strings.Join(comparators, " "))
+			case MatchArmType:
+				pattern = fmt.Sprintf("type=(%s)", StringifyAST(arm.ClassName))
+			}
+			items = append(items, fmt.Sprintf("arm[]=(%s block=(%s))", pattern, StringifyAST(arm.Block)))
+		}
+		if v.ElseBlock != nil {
+			items = append(items, fmt.Sprintf("else=(%s)", StringifyAST(v.ElseBlock)))
+		}
+		return fmt.Sprintf("$MATCH(%s)", strings.Join(items, " "))
+	case *ThrowStmt:
+		return fmt.Sprintf("$THROW(%s)", StringifyAST(v.ThrowExpr))
+	case *ImportStmt:
+		namespace := ""
+		if v.Namespace != nil {
+			namespace = StringifyAST(v.Namespace)
+		}
+		return fmt.Sprintf("$IMPORT(path=(%s) as=(%s))", StringifyAST(v.ImportPath), namespace)
 	case *PropertyDeclareStmt:
 		return fmt.Sprintf(
 			"$PD(id=(%s) expr=(%s))",